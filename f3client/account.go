@@ -3,6 +3,7 @@ package f3client
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"github.com/google/uuid"
 )
@@ -12,6 +13,52 @@ type AccountService struct {
 	ObjectType string
 }
 
+// AccountListOptions specifies the optional parameters to the AccountService.List
+// and AccountService.Iterate methods
+type AccountListOptions struct {
+	ListOptions
+	Filter AccountFilter
+}
+
+// AccountFilter narrows down the accounts returned by the list api, only the accounts
+// matching all of the non empty fields are returned
+//
+// See https://api-docs.form3.tech/api.html#organisation-accounts for the
+// supported filters
+type AccountFilter struct {
+	BankID        string
+	BankIDCode    string
+	AccountNumber string
+	Iban          string
+	CustomerID    string
+	Country       string
+}
+
+// values converts the list options into page and filter query parameters
+func (o *AccountListOptions) values() url.Values {
+	if o == nil {
+		return url.Values{}
+	}
+
+	v := o.ListOptions.values()
+
+	filters := map[string]string{
+		"filter[bank_id]":        o.Filter.BankID,
+		"filter[bank_id_code]":   o.Filter.BankIDCode,
+		"filter[account_number]": o.Filter.AccountNumber,
+		"filter[iban]":           o.Filter.Iban,
+		"filter[customer_id]":    o.Filter.CustomerID,
+		"filter[country]":        o.Filter.Country,
+	}
+	for key, value := range filters {
+		if value != "" {
+			v.Set(key, value)
+		}
+	}
+
+	return v
+}
+
 // Account represents an account in the form3 org section.
 //
 // See https://api-docs.form3.tech/api.html#organisation-accounts for
@@ -23,34 +70,190 @@ type Account struct {
 	CreatedOn      string            `json:"created_on,omitempty"`
 	ModifiedOn     string            `json:"modified_on,omitempty"`
 	Attributes     AccountAttributes `json:"attributes,omitempty"`
+
+	Relationships *AccountRelationships `json:"relationships,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (a *Account) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, a, "type")
+}
+
+func (a Account) MarshalJSON() ([]byte, error) {
+	return marshalExtra(a)
 }
 
 type AccountAttributes struct {
-	Country                 string   `json:"country,omitempty"`
-	BaseCurrency            string   `json:"base_currency,omitempty"`
-	BankID                  string   `json:"bank_id,omitempty"`
-	BankIDCode              string   `json:"bank_id_code,omitempty"`
-	Bic                     string   `json:"bic,omitempty"`
-	Iban                    string   `json:"iban,omitempty"`
-	CustomerID              string   `json:"customer_id,omitempty"`
-	Name                    []string `json:"name,omitempty"`
-	AlternativeNames        []string `json:"alternative_names,omitempty"`
-	AccountClassification   string   `json:"account_classification,omitempty"`
-	JointAccount            bool     `json:"joint_account,omitempty"`
-	AccountMatchingOptOut   bool     `json:"account_matching_opt_out,omitempty"`
-	SecondaryIdentification string   `json:"secondary_identification,omitempty"`
-	Switched                bool     `json:"switched,omitempty"`
-	ProcessingService       string   `json:"processing_service,omitempty"`
-	UserDefinedInformation  string   `json:"user_defined_information,omitempty"`
-	ValidationType          string   `json:"validation_type,omitempty"`
-	ReferenceMask           string   `json:"reference_mask,omitempty"`
-	AcceptanceQualifier     string   `json:"acceptance_qualifier,omitempty"`
-	AccountNumber           string   `json:"account_number,omitempty"`
-	Status                  string   `json:"status,omitempty"`
+	Country                 Country    `json:"country,omitempty"`
+	BaseCurrency            Currency   `json:"base_currency,omitempty"`
+	BankID                  string     `json:"bank_id,omitempty"`
+	BankIDCode              BankIDCode `json:"bank_id_code,omitempty"`
+	Bic                     BIC        `json:"bic,omitempty"`
+	Iban                    IBAN       `json:"iban,omitempty"`
+	CustomerID              string     `json:"customer_id,omitempty"`
+	Name                    []string   `json:"name,omitempty"`
+	AlternativeNames        []string   `json:"alternative_names,omitempty"`
+	AccountClassification   string     `json:"account_classification,omitempty"`
+	JointAccount            bool       `json:"joint_account,omitempty"`
+	AccountMatchingOptOut   bool       `json:"account_matching_opt_out,omitempty"`
+	SecondaryIdentification string     `json:"secondary_identification,omitempty"`
+	Switched                bool       `json:"switched,omitempty"`
+	ProcessingService       string     `json:"processing_service,omitempty"`
+	UserDefinedInformation  string     `json:"user_defined_information,omitempty"`
+	ValidationType          string     `json:"validation_type,omitempty"`
+	ReferenceMask           string     `json:"reference_mask,omitempty"`
+	AcceptanceQualifier     string     `json:"acceptance_qualifier,omitempty"`
+	AccountNumber           string     `json:"account_number,omitempty"`
+	Status                  string     `json:"status,omitempty"`
+
+	AlternativeBankAccountNames []string                           `json:"alternative_bank_account_names,omitempty"`
+	PrivateIdentification       *AccountPrivateIdentification      `json:"private_identification,omitempty"`
+	OrganisationIdentification  *AccountOrganisationIdentification `json:"organisation_identification,omitempty"`
+	NameMatchingStatus          string                             `json:"name_matching_status,omitempty"`
+	StatusReason                string                             `json:"status_reason,omitempty"`
+	UserDefinedData             []UserDefinedData                  `json:"user_defined_data,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (aa *AccountAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, aa)
+}
+
+func (aa AccountAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(aa)
+}
+
+// AccountPrivateIdentification identifies the person holding a personal account
+type AccountPrivateIdentification struct {
+	BirthDate      string   `json:"birth_date,omitempty"`
+	BirthCountry   string   `json:"birth_country,omitempty"`
+	Identification string   `json:"identification,omitempty"`
+	Address        []string `json:"address,omitempty"`
+	City           string   `json:"city,omitempty"`
+	Country        string   `json:"country,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (pi *AccountPrivateIdentification) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, pi)
+}
+
+func (pi AccountPrivateIdentification) MarshalJSON() ([]byte, error) {
+	return marshalExtra(pi)
+}
+
+// AccountOrganisationIdentification identifies the organisation holding a business account
+type AccountOrganisationIdentification struct {
+	Identification string   `json:"identification,omitempty"`
+	Actors         []Actor  `json:"actors,omitempty"`
+	Address        []string `json:"address,omitempty"`
+	City           string   `json:"city,omitempty"`
+	Country        string   `json:"country,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (oi *AccountOrganisationIdentification) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, oi)
+}
+
+func (oi AccountOrganisationIdentification) MarshalJSON() ([]byte, error) {
+	return marshalExtra(oi)
+}
+
+// Actor is a person acting on behalf of the organisation holding an account
+type Actor struct {
+	Name      []string `json:"name,omitempty"`
+	BirthDate string   `json:"birth_date,omitempty"`
+	Residency string   `json:"residency,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (a *Actor) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, a)
+}
+
+func (a Actor) MarshalJSON() ([]byte, error) {
+	return marshalExtra(a)
+}
+
+// UserDefinedData is a key value pair stored along with an account
+type UserDefinedData struct {
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ud *UserDefinedData) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ud)
+}
+
+func (ud UserDefinedData) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ud)
+}
+
+// AccountRelationships holds the references from an account to related resources
+type AccountRelationships struct {
+	MasterAccount *Relationship `json:"master_account,omitempty"`
+	AccountEvents *Relationship `json:"account_events,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ar *AccountRelationships) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ar)
+}
+
+func (ar AccountRelationships) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ar)
+}
+
+// Relationship is a reference to one or more related resources
+type Relationship struct {
+	Data []ResourceIdentifier `json:"data,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (r *Relationship) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, r)
+}
+
+func (r Relationship) MarshalJSON() ([]byte, error) {
+	return marshalExtra(r)
+}
+
+// ResourceIdentifier identifies a resource by its type and id
+type ResourceIdentifier struct {
+	Type string    `json:"type,omitempty"`
+	ID   uuid.UUID `json:"id,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ri *ResourceIdentifier) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ri)
+}
+
+func (ri ResourceIdentifier) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ri)
 }
 
 // Create creates an account using form3 account api
 //
+// If the api reports a conflict because an account with the same id already exists, and that
+// account is identical to the one being created, the existing account is fetched and no error
+// is returned. This makes it safe to repeat a create whose outcome is unknown, e.g. after a timeout.
+//
+// Before the request is sent the mandatory fields and the codes of the account are checked, along
+// with the rules of the validator of the client, see WithAccountValidator. A ValidationError
+// holding every broken rule is returned.
+//
 // For details related to the attributes required can be found
 // https://api-docs.form3.tech/api.html#organisation-accounts-create
 func (as *AccountService) Create(ctx context.Context, account *Account) error {
@@ -58,11 +261,10 @@ func (as *AccountService) Create(ctx context.Context, account *Account) error {
 
 	path := "/v1/organisation/accounts"
 
-	// validate for mandatory account fields before creating new request
-	if account.Attributes.Country == "" {
-		return NewArgError("country", "country is mandatory for account create request")
-	} else if account.Attributes.Name == nil {
-		return NewArgError("name", "names are mandatory for account create request")
+	// validate the account before creating new request, all the broken rules are returned at once
+	err = validateAccount(account, as.client.accountValidator)
+	if err != nil {
+		return err
 	}
 
 	// create new request
@@ -73,7 +275,15 @@ func (as *AccountService) Create(ctx context.Context, account *Account) error {
 
 	// send the request and catch the response
 	resp, err := as.client.SendRequest(ctx, req)
-	if err != nil {
+	if IsConflict(err) && !IsVersionMismatch(err) {
+		// an earlier attempt might have created the account already
+		existing, fetchErr := as.Fetch(ctx, account.ID)
+		if fetchErr == nil && isDuplicate(account, existing) {
+			*account = *existing
+			return nil
+		}
+		return err
+	} else if err != nil {
 		return err
 	}
 
@@ -112,6 +322,93 @@ func (as *AccountService) Fetch(ctx context.Context, accountId uuid.UUID) (*Acco
 	return acc, nil
 }
 
+// List gets a single page of form3 account objects
+//
+// The page to be fetched and the filters to be applied are controlled through opts, when opts is nil
+// the first page with the api default page size is returned. The links returned along with
+// the accounts point to the first, last, next and previous pages.
+func (as *AccountService) List(ctx context.Context, opts *AccountListOptions) ([]Account, Links, error) {
+	path := addQuery("/v1/organisation/accounts", opts.values())
+
+	req, err := as.client.NewRequest(ctx, Get, path, as.ObjectType, nil)
+	if err != nil {
+		return nil, Links{}, err
+	}
+
+	resp, err := as.client.SendListRequest(ctx, req)
+	if err != nil {
+		return nil, Links{}, err
+	} else if resp == nil {
+		return []Account{}, Links{}, nil
+	}
+
+	accounts := []Account{}
+	err = resp.ConvertTo(&accounts)
+	if err != nil {
+		return nil, Links{}, err
+	}
+
+	return accounts, resp.Links, nil
+}
+
+// Iterate returns an AccountIterator that walks through all the accounts matching the filter
+// starting from the page specified in opts, until there are no more pages left
+func (as *AccountService) Iterate(opts *AccountListOptions) *AccountIterator {
+	return &AccountIterator{
+		it: newResourceIterator(as.client, as.ObjectType, addQuery("/v1/organisation/accounts", opts.values()), nil),
+	}
+}
+
+// AccountIterator iterates over the accounts returned by the list api one account at a time,
+// fetching the next page whenever the current one is exhausted
+//
+// Typical usage
+//
+//	it := client.Accounts.Iterate(nil)
+//	for it.Next(ctx) {
+//		account := it.Account()
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error
+//	}
+type AccountIterator struct {
+	it      resourceIterator
+	current Account
+}
+
+// Next advances the iterator to the next account, it returns false when there are no
+// more accounts left or an error occurs while fetching a page
+func (ai *AccountIterator) Next(ctx context.Context) bool {
+	ai.current = Account{}
+	return ai.it.next(ctx) && ai.it.decode(&ai.current)
+}
+
+// Account returns the account the iterator currently points to
+func (ai *AccountIterator) Account() Account {
+	return ai.current
+}
+
+// Err returns the first error encountered while iterating, if any
+func (ai *AccountIterator) Err() error {
+	return ai.it.err
+}
+
+// Update changes a form3 account using the form3 account api, the account is updated in place
+// with the account sent back by the api
+//
+// Account.Version must be the current version of the account. If the account was changed in the
+// meantime a *VersionConflictError is returned, the account has to be fetched again and the change
+// applied on the latest version.
+func (as *AccountService) Update(ctx context.Context, account *Account) error {
+	if account.ID == uuid.Nil {
+		return NewArgError("id", "id is mandatory for account update request")
+	}
+
+	path := "/v1/organisation/accounts/" + account.ID.String()
+
+	return as.update(ctx, path, as.ObjectType, account.ID, account.Version, account)
+}
+
 // Delete delets a form3 account from form3's database
 // Needs the account Id (uuid) and account version (int) to be supplied
 //
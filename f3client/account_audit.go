@@ -0,0 +1,137 @@
+package f3client
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// AuditEntry represents a change made to an account, the attributes of the account before and
+// after the change are decoded into BeforeData and AfterData.
+//
+// See the Audit Entries section of https://api-docs.form3.tech/api.html for
+// more information about fields.
+type AuditEntry struct {
+	ID             uuid.UUID            `json:"id,omitempty"`
+	Version        int                  `json:"version,omitempty"`
+	OrganisationID uuid.UUID            `json:"organisation_id,omitempty"`
+	CreatedOn      string               `json:"created_on,omitempty"`
+	ModifiedOn     string               `json:"modified_on,omitempty"`
+	Attributes     AuditEntryAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (e *AuditEntry) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, e, "type")
+}
+
+func (e AuditEntry) MarshalJSON() ([]byte, error) {
+	return marshalExtra(e)
+}
+
+type AuditEntryAttributes struct {
+	ActionTime  string    `json:"action_time,omitempty"`
+	ActionedBy  uuid.UUID `json:"actioned_by,omitempty"`
+	Description string    `json:"description,omitempty"`
+	RecordType  string    `json:"record_type,omitempty"`
+	RecordID    uuid.UUID `json:"record_id,omitempty"`
+
+	// BeforeData is nil for the entry recording the creation of the account
+	BeforeData *AccountAttributes `json:"before_data,omitempty"`
+	// AfterData is nil for the entry recording the deletion of the account
+	AfterData *AccountAttributes `json:"after_data,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (aea *AuditEntryAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, aea)
+}
+
+func (aea AuditEntryAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(aea)
+}
+
+// StatusChange returns the status of the account before and after the change, changed is false
+// when the change left the status as it was
+func (e *AuditEntry) StatusChange() (from, to string, changed bool) {
+	if e.Attributes.BeforeData != nil {
+		from = e.Attributes.BeforeData.Status
+	}
+	if e.Attributes.AfterData != nil {
+		to = e.Attributes.AfterData.Status
+	}
+
+	return from, to, from != to
+}
+
+// AccountEvent represents an event that happened to an account, example the account being
+// confirmed or failing once it has been processed after creation.
+type AccountEvent struct {
+	ID             uuid.UUID              `json:"id,omitempty"`
+	Version        int                    `json:"version,omitempty"`
+	OrganisationID uuid.UUID              `json:"organisation_id,omitempty"`
+	CreatedOn      string                 `json:"created_on,omitempty"`
+	ModifiedOn     string                 `json:"modified_on,omitempty"`
+	Attributes     AccountEventAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ae *AccountEvent) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ae, "type")
+}
+
+func (ae AccountEvent) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ae)
+}
+
+type AccountEventAttributes struct {
+	EventType    string `json:"event_type,omitempty"`
+	Status       string `json:"status,omitempty"`
+	StatusReason string `json:"status_reason,omitempty"`
+	Description  string `json:"description,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (aea *AccountEventAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, aea)
+}
+
+func (aea AccountEventAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(aea)
+}
+
+// AuditEntries gets a single page of the audit entries of the account, when opts is nil the first
+// page with the api default page size is returned
+func (as *AccountService) AuditEntries(ctx context.Context, accountId uuid.UUID, opts *ListOptions) ([]AuditEntry, Links, error) {
+	entries := []AuditEntry{}
+
+	query := opts.values()
+	query.Set("filter[record_type]", as.ObjectType)
+	query.Set("filter[record_id]", accountId.String())
+
+	links, err := as.list(ctx, addQuery("/v1/audit/entries", query), "audit_entries", &entries)
+	if err != nil {
+		return nil, Links{}, err
+	}
+
+	return entries, links, nil
+}
+
+// Events gets a single page of the events of the account, when opts is nil the first
+// page with the api default page size is returned
+func (as *AccountService) Events(ctx context.Context, accountId uuid.UUID, opts *ListOptions) ([]AccountEvent, Links, error) {
+	events := []AccountEvent{}
+
+	path := "/v1/organisation/accounts/" + accountId.String() + "/events"
+
+	links, err := as.list(ctx, addQuery(path, opts.values()), "account_events", &events)
+	if err != nil {
+		return nil, Links{}, err
+	}
+
+	return events, links, nil
+}
@@ -0,0 +1,105 @@
+package f3client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unit_AccountService_AuditEntries(t *testing.T) {
+	var actualPath, actualRecordType, actualRecordID, actualPageSize string
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		actualRecordType = r.URL.Query().Get("filter[record_type]")
+		actualRecordID = r.URL.Query().Get("filter[record_id]")
+		actualPageSize = r.URL.Query().Get("page[size]")
+		w.Write([]byte(`{"data": [
+			{"id": "0a1b2c3d-4e5f-4a6b-8c7d-8e9f0a1b2c3d", "type": "audit_entries", "organisation_id": "ee2fb143-6dfe-4787-b183-de8ddd4164d1",
+			 "attributes": {"action_time": "2021-10-03T13:44:27.809Z", "description": "Account created", "record_type": "accounts",
+			  "record_id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "after_data": {"country": "GB", "status": "pending"}}},
+			{"id": "1b2c3d4e-5f6a-4b7c-9d8e-9f0a1b2c3d4e", "type": "audit_entries", "organisation_id": "ee2fb143-6dfe-4787-b183-de8ddd4164d1",
+			 "attributes": {"action_time": "2021-10-03T13:45:01.112Z", "description": "Account confirmed", "record_type": "accounts",
+			  "record_id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "before_data": {"country": "GB", "status": "pending"},
+			  "after_data": {"country": "GB", "status": "confirmed"}}}]}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	accountId := uuid.MustParse("bc8fb900-d6fd-41d0-b187-dc23ba928712")
+
+	entries, _, err := client.Accounts.AuditEntries(context.Background(), accountId, &f3client.ListOptions{PageSize: 50})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/audit/entries", actualPath)
+	assert.Equal(t, "accounts", actualRecordType)
+	assert.Equal(t, accountId.String(), actualRecordID)
+	assert.Equal(t, "50", actualPageSize)
+
+	if assert.Len(t, entries, 2) {
+		assert.Nil(t, entries[0].Attributes.BeforeData)
+		assert.Equal(t, accountId, entries[0].Attributes.RecordID)
+
+		from, to, changed := entries[1].StatusChange()
+		assert.True(t, changed)
+		assert.Equal(t, "pending", from)
+		assert.Equal(t, "confirmed", to)
+	}
+}
+
+func Test_Unit_AuditEntry_StatusChange_Unchanged(t *testing.T) {
+	entry := f3client.AuditEntry{
+		Attributes: f3client.AuditEntryAttributes{
+			BeforeData: &f3client.AccountAttributes{Status: "confirmed", CustomerID: "old"},
+			AfterData:  &f3client.AccountAttributes{Status: "confirmed", CustomerID: "new"},
+		},
+	}
+
+	_, _, changed := entry.StatusChange()
+
+	assert.False(t, changed)
+}
+
+func Test_Unit_AccountService_Events(t *testing.T) {
+	var actualPath string
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		w.Write([]byte(`{"data": [{"id": "2c3d4e5f-6a7b-4c8d-8e9f-0a1b2c3d4e5f", "type": "account_events",
+			"attributes": {"event_type": "status_changed", "status": "failed", "status_reason": "invalid bank id"}}]}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	events, _, err := client.Accounts.Events(context.Background(), uuid.MustParse("bc8fb900-d6fd-41d0-b187-dc23ba928712"), nil)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/organisation/accounts/bc8fb900-d6fd-41d0-b187-dc23ba928712/events", actualPath)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "failed", events[0].Attributes.Status)
+		assert.Equal(t, "invalid bank id", events[0].Attributes.StatusReason)
+	}
+}
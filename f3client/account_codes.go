@@ -0,0 +1,163 @@
+package f3client
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The types below give the codes used by accounts a type of their own, so that a typo is caught
+// locally by IsValid instead of being rejected by the api. They are plain strings on the wire and
+// values sent back by the api are decoded as they are, without being validated.
+
+// Country is an ISO 3166-1 alpha-2 country code, example GB
+type Country string
+
+// IsValid reports whether the country is an assigned ISO 3166-1 alpha-2 code
+func (c Country) IsValid() bool {
+	_, ok := countryCodes[string(c)]
+	return ok
+}
+
+// Currency is an ISO 4217 currency code, example GBP
+type Currency string
+
+// IsValid reports whether the currency is an active ISO 4217 code
+func (c Currency) IsValid() bool {
+	_, ok := currencyCodes[string(c)]
+	return ok
+}
+
+// BIC is a SWIFT business identifier code, example NWBKGB22
+//
+// A BIC has no checksum, it is made of a 4 letter institution code, the country code of the
+// institution, a 2 character location code and an optional 3 character branch code.
+type BIC string
+
+var bicPattern = regexp.MustCompile(`^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+
+// IsValid reports whether the bic is well formed and has a valid country code
+func (b BIC) IsValid() bool {
+	return bicPattern.MatchString(string(b)) && b.Country().IsValid()
+}
+
+// Country returns the country code part of the bic
+func (b BIC) Country() Country {
+	if len(b) < 6 {
+		return ""
+	}
+	return Country(b[4:6])
+}
+
+// IBAN is an international bank account number in its electronic format, i.e. without spaces,
+// example GB29NWBK60161331926819
+type IBAN string
+
+var ibanPattern = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$`)
+
+// IsValid reports whether the iban is well formed, has the length used by its country and
+// passes the mod-97 checksum
+func (i IBAN) IsValid() bool {
+	if !ibanPattern.MatchString(string(i)) || !i.Country().IsValid() {
+		return false
+	}
+
+	if length, ok := ibanLengths[string(i.Country())]; ok && len(i) != length {
+		return false
+	}
+
+	// move the country code and check digits to the end, convert letters to numbers
+	// (A=10 ... Z=35) and compute the remainder digit by digit to avoid big numbers
+	rearranged := string(i[4:]) + string(i[:4])
+	remainder := 0
+	for _, r := range rearranged {
+		if r >= 'A' && r <= 'Z' {
+			remainder = (remainder*100 + int(r-'A'+10)) % 97
+		} else {
+			remainder = (remainder*10 + int(r-'0')) % 97
+		}
+	}
+
+	return remainder == 1
+}
+
+// Country returns the country code part of the iban
+func (i IBAN) Country() Country {
+	if len(i) < 2 {
+		return ""
+	}
+	return Country(i[:2])
+}
+
+// BankIDCode identifies the national scheme the bank id of an account belongs to
+type BankIDCode string
+
+// Bank id codes documented by form3
+const (
+	BankIDCodeGB BankIDCode = "GBDSC"
+	BankIDCodeAU BankIDCode = "AUBSB"
+	BankIDCodeBE BankIDCode = "BE"
+	BankIDCodeCA BankIDCode = "CACPA"
+	BankIDCodeCH BankIDCode = "CHBCC"
+	BankIDCodeDE BankIDCode = "DEBLZ"
+	BankIDCodeES BankIDCode = "ESNCC"
+	BankIDCodeFR BankIDCode = "FR"
+	BankIDCodeGR BankIDCode = "GRBIC"
+	BankIDCodeHK BankIDCode = "HKNCC"
+	BankIDCodeIT BankIDCode = "ITNCC"
+	BankIDCodeLU BankIDCode = "LULUX"
+	BankIDCodePL BankIDCode = "PLKNR"
+	BankIDCodePT BankIDCode = "PTNCC"
+	BankIDCodeUS BankIDCode = "USABA"
+)
+
+// IsValid reports whether the bank id code is one of the bank id codes documented by form3
+func (b BankIDCode) IsValid() bool {
+	switch b {
+	case BankIDCodeGB, BankIDCodeAU, BankIDCodeBE, BankIDCodeCA, BankIDCodeCH, BankIDCodeDE, BankIDCodeES, BankIDCodeFR,
+		BankIDCodeGR, BankIDCodeHK, BankIDCodeIT, BankIDCodeLU, BankIDCodePL, BankIDCodePT, BankIDCodeUS:
+		return true
+	}
+	return false
+}
+
+// codeSet builds a set out of space separated codes
+func codeSet(codes string) map[string]struct{} {
+	set := map[string]struct{}{}
+	for _, code := range strings.Fields(codes) {
+		set[code] = struct{}{}
+	}
+	return set
+}
+
+var countryCodes = codeSet(`
+	AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS
+	BT BV BW BY BZ CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE
+	EG EH ER ES ET FI FJ FK FM FO FR GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM
+	HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE JM JO JP KE KG KH KI KM KN KP KR KW KY KZ LA LB LC
+	LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW MX MY MZ NA
+	NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR PS PT PW PY QA RE RO RS RU RW
+	SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO
+	TR TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW
+`)
+
+var currencyCodes = codeSet(`
+	AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BRL BSD BTN BWP BYN
+	BZD CAD CDF CHF CLP CNY COP CRC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS
+	GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW
+	KWD KYD KZT LAK LBP LKR LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MYR MZN NAD
+	NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD
+	SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX USD UYU UZS VES
+	VND VUV WST XAF XCD XOF XPF YER ZAR ZMW ZWL
+`)
+
+// ibanLengths holds the length of the ibans of the countries in the SWIFT iban registry
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BR": 29,
+	"BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DK": 18, "DO": 28, "EE": 20, "EG": 29,
+	"ES": 24, "FI": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27, "GT": 28,
+	"HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27, "JO": 30, "KW": 30, "KZ": 20,
+	"LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "MC": 27, "MD": 24, "ME": 22, "MK": 19,
+	"MR": 27, "MT": 31, "MU": 30, "NL": 18, "NO": 15, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29,
+	"RO": 24, "RS": 22, "SA": 24, "SC": 31, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24,
+}
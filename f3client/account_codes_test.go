@@ -0,0 +1,117 @@
+package f3client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unit_IBAN_IsValid(t *testing.T) {
+	cases := map[f3client.IBAN]bool{
+		"GB29NWBK60161331926819":      true,
+		"DE89370400440532013000":      true,
+		"FR1420041010050500013M02606": true,
+		"GB28NWBK60161331926819":      false, // checksum does not match
+		"GB29NWBK6016133192681":       false, // too short for GB
+		"gb29nwbk60161331926819":      false, // not in electronic format
+		"GB29 NWBK 6016 1331 9268 19": false,
+		"ZZ29NWBK60161331926819":      false, // unknown country
+		"":                            false,
+	}
+
+	for iban, expected := range cases {
+		assert.Equal(t, expected, iban.IsValid(), string(iban))
+	}
+}
+
+func Test_Unit_BIC_IsValid(t *testing.T) {
+	cases := map[f3client.BIC]bool{
+		"NWBKGB22":    true,
+		"DEUTDEFF500": true,
+		"NWBKGB2":     false,
+		"NWBKZZ22":    false, // unknown country
+		"NWBK GB22":   false,
+		"ASD":         false,
+	}
+
+	for bic, expected := range cases {
+		assert.Equal(t, expected, bic.IsValid(), string(bic))
+	}
+
+	assert.Equal(t, f3client.Country("DE"), f3client.BIC("DEUTDEFF500").Country())
+}
+
+func Test_Unit_Codes_IsValid(t *testing.T) {
+	assert.True(t, f3client.Country("GB").IsValid())
+	assert.False(t, f3client.Country("UK").IsValid())
+	assert.False(t, f3client.Country("gb").IsValid())
+
+	assert.True(t, f3client.Currency("GBP").IsValid())
+	assert.False(t, f3client.Currency("GPB").IsValid())
+
+	assert.True(t, f3client.BankIDCodeGB.IsValid())
+	assert.True(t, f3client.BankIDCode("DEBLZ").IsValid())
+	assert.False(t, f3client.BankIDCode("1234ASD").IsValid())
+}
+
+func Test_Unit_Codes_JSON(t *testing.T) {
+	attributes := f3client.AccountAttributes{Country: "GB", BaseCurrency: "GBP", Bic: "NWBKGB22", Iban: "GB29NWBK60161331926819", BankIDCode: f3client.BankIDCodeGB}
+
+	encoded, err := json.Marshal(attributes)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.JSONEq(t, `{"country": "GB", "base_currency": "GBP", "bic": "NWBKGB22", "iban": "GB29NWBK60161331926819", "bank_id_code": "GBDSC"}`, string(encoded))
+
+	// values sent back by the api are decoded without being validated
+	var decoded f3client.AccountAttributes
+	err = json.Unmarshal([]byte(`{"country": "XX", "bic": "ASD"}`), &decoded)
+
+	assert.NoError(t, err)
+	assert.Equal(t, f3client.Country("XX"), decoded.Country)
+	assert.Equal(t, f3client.BIC("ASD"), decoded.Bic)
+}
+
+func Test_Unit_AccountService_Create_InvalidCodes(t *testing.T) {
+	var calls int
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	cases := map[string]f3client.AccountAttributes{
+		"country":       {Country: "UK"},
+		"base currency": {Country: "GB", BaseCurrency: "GPB"},
+		"bank id code":  {Country: "GB", BankIDCode: "GBSDC"},
+		"bic":           {Country: "GB", Bic: "NWBK22"},
+		"iban":          {Country: "GB", Iban: "GB28NWBK60161331926819"},
+	}
+
+	for name, attributes := range cases {
+		attributes.Name = []string{"Jane Doe"}
+		account := &f3client.Account{ID: uuid.New(), OrganisationID: uuid.New(), Attributes: attributes}
+
+		err = client.Accounts.Create(context.Background(), account)
+
+		var targetErr *f3client.ArgumentError
+		assert.ErrorAs(t, err, &targetErr, name)
+	}
+
+	assert.Equal(t, 0, calls)
+}
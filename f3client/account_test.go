@@ -2,8 +2,10 @@ package f3client_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
@@ -73,8 +75,11 @@ func Test_Unit_AccountService_CreateAccount(t *testing.T) {
 		ID:             resourceUUID,
 		OrganisationID: organisationUUID,
 		Attributes: f3client.AccountAttributes{
-			Country: "US",
-			Name:    []string{"Jon Doe", "Jane Doe"},
+			Country:    "US",
+			BankID:     "021000021",
+			BankIDCode: f3client.BankIDCodeUS,
+			Bic:        "CHASUS33",
+			Name:       []string{"Jon Doe", "Jane Doe"},
 		},
 	}
 
@@ -134,16 +139,22 @@ func Test_Unit_AccountService_Create_RequestValidationFailed_ID(t *testing.T) {
 	actual := &f3client.Account{
 		OrganisationID: organisationUUID,
 		Attributes: f3client.AccountAttributes{
-			Country: "US",
-			Name:    []string{"Jon Doe", "Jane Doe"},
+			Country:    "US",
+			BankID:     "021000021",
+			BankIDCode: f3client.BankIDCodeUS,
+			Bic:        "CHASUS33",
+			Name:       []string{"Jon Doe", "Jane Doe"},
 		},
 	}
 
 	expected := &f3client.Account{
 		OrganisationID: organisationUUID,
 		Attributes: f3client.AccountAttributes{
-			Country: "US",
-			Name:    []string{"Jon Doe", "Jane Doe"},
+			Country:    "US",
+			BankID:     "021000021",
+			BankIDCode: f3client.BankIDCodeUS,
+			Bic:        "CHASUS33",
+			Name:       []string{"Jon Doe", "Jane Doe"},
 		},
 	}
 
@@ -181,16 +192,22 @@ func Test_Unit_AccountService_Create_RequestValidationFailed_OrganisationID(t *t
 	actual := &f3client.Account{
 		ID: accountid,
 		Attributes: f3client.AccountAttributes{
-			Country: "US",
-			Name:    []string{"Jon Doe", "Jane Doe"},
+			Country:    "US",
+			BankID:     "021000021",
+			BankIDCode: f3client.BankIDCodeUS,
+			Bic:        "CHASUS33",
+			Name:       []string{"Jon Doe", "Jane Doe"},
 		},
 	}
 
 	expected := &f3client.Account{
 		ID: accountid,
 		Attributes: f3client.AccountAttributes{
-			Country: "US",
-			Name:    []string{"Jon Doe", "Jane Doe"},
+			Country:    "US",
+			BankID:     "021000021",
+			BankIDCode: f3client.BankIDCodeUS,
+			Bic:        "CHASUS33",
+			Name:       []string{"Jon Doe", "Jane Doe"},
 		},
 	}
 
@@ -248,8 +265,9 @@ func Test_Unit_AccountService_Create_RequestValidationFailed_Country(t *testing.
 
 	err = client.Accounts.Create(context.Background(), actual)
 	if err != nil {
-		assert.IsType(t, &f3client.ArgumentError{}, err)
-		assert.EqualError(t, err, "country : country is mandatory for account create request")
+		var argErr *f3client.ArgumentError
+		assert.ErrorAs(t, err, &argErr)
+		assert.EqualError(t, err, "country : country is mandatory")
 	}
 
 	assert.Equal(t, expected, actual)
@@ -301,8 +319,9 @@ func Test_Unit_AccountService_Create_RequestValidationFailed_Names(t *testing.T)
 
 	err = client.Accounts.Create(context.Background(), actual)
 	if err != nil {
-		assert.IsType(t, &f3client.ArgumentError{}, err)
-		assert.EqualError(t, err, "name : names are mandatory for account create request")
+		var argErr *f3client.ArgumentError
+		assert.ErrorAs(t, err, &argErr)
+		assert.EqualError(t, err, "name : names are mandatory")
 	}
 
 	assert.Equal(t, expected, actual)
@@ -453,6 +472,7 @@ func Test_Unit_AccountService_Fetch_NotFound(t *testing.T) {
 	actual, err := client.Accounts.Fetch(context.Background(), resourceUUID)
 	if err != nil {
 		assert.EqualError(t, err, "Account Not Found")
+		assert.True(t, f3client.IsNotFound(err))
 	}
 
 	expected := new(f3client.Account)
@@ -519,9 +539,424 @@ func Test_Unit_AccountService_Version_Conflict(t *testing.T) {
 	actual, err := client.Accounts.Delete(context.Background(), resourceUUID, 1)
 	if err != nil {
 		assert.EqualError(t, err, "Specified version incorrect")
+		assert.True(t, f3client.IsVersionMismatch(err))
 	}
 
 	expected := false
 
 	assert.Equal(t, expected, actual)
 }
+
+func Test_Unit_AccountService_List(t *testing.T) {
+	var actualQuery string
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualQuery = r.URL.RawQuery
+		w.Write([]byte(`
+				{
+					"data": [
+						{
+							"attributes": {
+								"country": "GB",
+								"name": ["Jon Doe"]
+							},
+							"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712",
+							"organisation_id": "ee2fb143-6dfe-4787-b183-de8ddd4164d1",
+							"type": "accounts",
+							"version": 0
+						},
+						{
+							"attributes": {
+								"country": "FR",
+								"name": ["Jane Doe"]
+							},
+							"id": "2b3f6a5e-1b7a-4c55-8c0d-3e8c0f6d1f1a",
+							"organisation_id": "ee2fb143-6dfe-4787-b183-de8ddd4164d1",
+							"type": "accounts",
+							"version": 2
+						}
+					],
+					"links": {
+						"first": "/v1/organisation/accounts?page%5Bnumber%5D=first&page%5Bsize%5D=2",
+						"last": "/v1/organisation/accounts?page%5Bnumber%5D=last&page%5Bsize%5D=2",
+						"next": "/v1/organisation/accounts?page%5Bnumber%5D=2&page%5Bsize%5D=2",
+						"self": "/v1/organisation/accounts?page%5Bnumber%5D=1&page%5Bsize%5D=2"
+					}
+				}
+			  `))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	// client is the Form 3 API client being tested and is
+	// taking the mock server url.
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	accounts, links, err := client.Accounts.List(context.Background(), &f3client.AccountListOptions{ListOptions: f3client.ListOptions{PageNumber: 1, PageSize: 2}})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	organisationUUID, _ := uuid.Parse("ee2fb143-6dfe-4787-b183-de8ddd4164d1")
+	firstUUID, _ := uuid.Parse("bc8fb900-d6fd-41d0-b187-dc23ba928712")
+	secondUUID, _ := uuid.Parse("2b3f6a5e-1b7a-4c55-8c0d-3e8c0f6d1f1a")
+
+	expected := []f3client.Account{
+		{
+			ID:             firstUUID,
+			OrganisationID: organisationUUID,
+			Attributes: f3client.AccountAttributes{
+				Country: "GB",
+				Name:    []string{"Jon Doe"},
+			},
+		},
+		{
+			ID:             secondUUID,
+			OrganisationID: organisationUUID,
+			Version:        2,
+			Attributes: f3client.AccountAttributes{
+				Country: "FR",
+				Name:    []string{"Jane Doe"},
+			},
+		},
+	}
+
+	assert.Equal(t, "page%5Bnumber%5D=1&page%5Bsize%5D=2", actualQuery)
+	assert.Equal(t, expected, accounts)
+	assert.Equal(t, "/v1/organisation/accounts?page%5Bnumber%5D=2&page%5Bsize%5D=2", links.Next)
+}
+
+func Test_Unit_AccountService_List_Empty(t *testing.T) {
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": [], "links": {"self": "/v1/organisation/accounts"}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	accounts, _, err := client.Accounts.List(context.Background(), nil)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Empty(t, accounts)
+}
+
+func Test_Unit_AccountService_Iterate(t *testing.T) {
+	// mock the server, every page holds one account and points to the next page
+	// until page 2 which is the last one
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page[number]")
+		switch page {
+		case "", "0":
+			w.Write([]byte(`{"data": [{"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "type": "accounts", "attributes": {"country": "GB"}}],
+				"links": {"next": "/v1/organisation/accounts?page%5Bnumber%5D=1&page%5Bsize%5D=1", "last": "/v1/organisation/accounts?page%5Bnumber%5D=2&page%5Bsize%5D=1"}}`))
+		case "1":
+			w.Write([]byte(`{"data": [{"id": "2b3f6a5e-1b7a-4c55-8c0d-3e8c0f6d1f1a", "type": "accounts", "attributes": {"country": "FR"}}],
+				"links": {"next": "/v1/organisation/accounts?page%5Bnumber%5D=2&page%5Bsize%5D=1", "last": "/v1/organisation/accounts?page%5Bnumber%5D=2&page%5Bsize%5D=1"}}`))
+		case "2":
+			w.Write([]byte(`{"data": [{"id": "4e1c3b0a-7a4d-4b52-9c76-0a3f1c2e5d11", "type": "accounts", "attributes": {"country": "DE"}}],
+				"links": {"self": "/v1/organisation/accounts?page%5Bnumber%5D=2&page%5Bsize%5D=1", "last": "/v1/organisation/accounts?page%5Bnumber%5D=2&page%5Bsize%5D=1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error_message": "page not found"}`))
+		}
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	countries := []f3client.Country{}
+	it := client.Accounts.Iterate(&f3client.AccountListOptions{ListOptions: f3client.ListOptions{PageSize: 1}})
+	for it.Next(context.Background()) {
+		countries = append(countries, it.Account().Attributes.Country)
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []f3client.Country{"GB", "FR", "DE"}, countries)
+}
+
+func Test_Unit_AccountService_Iterate_Error(t *testing.T) {
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error_code": "Internal", "error_message": "something went wrong"}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	it := client.Accounts.Iterate(nil)
+
+	assert.False(t, it.Next(context.Background()))
+	assert.EqualError(t, it.Err(), "something went wrong")
+}
+
+func Test_Unit_AccountService_List_Filter(t *testing.T) {
+	var actualQuery url.Values
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualQuery = r.URL.Query()
+		w.Write([]byte(`{"data": [{"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "type": "accounts",
+			"attributes": {"country": "GB", "bank_id": "400300", "account_number": "41426819", "customer_id": "cust-1"}}]}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	opts := &f3client.AccountListOptions{
+		Filter: f3client.AccountFilter{
+			BankID:        "400300",
+			AccountNumber: "41426819",
+			CustomerID:    "cust-1",
+			Country:       "GB",
+		},
+	}
+
+	accounts, _, err := client.Accounts.List(context.Background(), opts)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	expected := url.Values{
+		"filter[bank_id]":        []string{"400300"},
+		"filter[account_number]": []string{"41426819"},
+		"filter[customer_id]":    []string{"cust-1"},
+		"filter[country]":        []string{"GB"},
+	}
+
+	assert.Equal(t, expected, actualQuery)
+	if assert.Len(t, accounts, 1) {
+		assert.Equal(t, "41426819", accounts[0].Attributes.AccountNumber)
+	}
+}
+
+func Test_Unit_AccountService_Update(t *testing.T) {
+	var actualMethod, actualPath string
+	var actualBody map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualMethod, actualPath = r.Method, r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.Write([]byte(`
+				{
+					"data": {
+						"attributes": {
+							"country": "GB",
+							"name": ["Jon Smith"]
+						},
+						"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712",
+						"organisation_id": "ee2fb143-6dfe-4787-b183-de8ddd4164d1",
+						"modified_on": "2021-10-04T10:00:00.000Z",
+						"type": "accounts",
+						"version": 3
+					}
+				}
+			  `))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	resourceUUID, _ := uuid.Parse("bc8fb900-d6fd-41d0-b187-dc23ba928712")
+	organisationUUID, _ := uuid.Parse("ee2fb143-6dfe-4787-b183-de8ddd4164d1")
+
+	actual := &f3client.Account{
+		ID:             resourceUUID,
+		OrganisationID: organisationUUID,
+		Version:        2,
+		Attributes: f3client.AccountAttributes{
+			Country: "GB",
+			Name:    []string{"Jon Smith"},
+		},
+	}
+
+	err = client.Accounts.Update(context.Background(), actual)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, f3client.Patch, actualMethod)
+	assert.Equal(t, "/v1/organisation/accounts/bc8fb900-d6fd-41d0-b187-dc23ba928712", actualPath)
+	assert.Equal(t, float64(2), actualBody["data"].(map[string]interface{})["version"])
+	assert.Equal(t, 3, actual.Version)
+	assert.Equal(t, "2021-10-04T10:00:00.000Z", actual.ModifiedOn)
+}
+
+func Test_Unit_AccountService_Update_VersionConflict(t *testing.T) {
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{
+			"error_code": "Conflict",
+			"error_message": "Specified version incorrect"
+			}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	resourceUUID, _ := uuid.Parse("bc8fb900-d6fd-41d0-b187-dc23ba928712")
+	organisationUUID, _ := uuid.Parse("ee2fb143-6dfe-4787-b183-de8ddd4164d1")
+
+	actual := &f3client.Account{
+		ID:             resourceUUID,
+		OrganisationID: organisationUUID,
+		Version:        1,
+		Attributes: f3client.AccountAttributes{
+			Country: "GB",
+			Name:    []string{"Jon Smith"},
+		},
+	}
+
+	err = client.Accounts.Update(context.Background(), actual)
+
+	var conflictErr *f3client.VersionConflictError
+	if assert.ErrorAs(t, err, &conflictErr) {
+		assert.Equal(t, resourceUUID, conflictErr.ID)
+		assert.Equal(t, 1, conflictErr.Version)
+		assert.Equal(t, http.StatusConflict, conflictErr.Err.StatusCode)
+		assert.True(t, f3client.IsVersionMismatch(err))
+	}
+}
+
+func Test_Unit_AccountService_Update_Conflict(t *testing.T) {
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{
+			"error_code": "Conflict",
+			"error_message": "Account is being closed"
+			}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	actual := &f3client.Account{
+		ID:             uuid.New(),
+		OrganisationID: uuid.New(),
+		Version:        1,
+		Attributes: f3client.AccountAttributes{
+			Country: "GB",
+			Name:    []string{"Jon Smith"},
+		},
+	}
+
+	err = client.Accounts.Update(context.Background(), actual)
+
+	// a conflict that is not about the version is returned as it is
+	assert.IsType(t, &f3client.APIError{}, err)
+	assert.False(t, f3client.IsVersionMismatch(err))
+	assert.True(t, f3client.IsConflict(err))
+
+	var apiErr *f3client.APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, "Account is being closed", apiErr.Message)
+	}
+}
+
+func Test_Unit_AccountService_Update_MissingID(t *testing.T) {
+	client, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	err = client.Accounts.Update(context.Background(), &f3client.Account{})
+
+	var targetErr *f3client.ArgumentError
+	assert.ErrorAs(t, err, &targetErr)
+}
+
+func Test_Unit_AccountService_Fetch_AllFields(t *testing.T) {
+	fixture := readTestFile("account_full.json")
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(fixture)
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	account, err := client.Accounts.Fetch(context.Background(), uuid.MustParse("bc8fb900-d6fd-41d0-b187-dc23ba928712"))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, []string{"Acme Trading"}, account.Attributes.AlternativeBankAccountNames)
+	assert.Equal(t, "unspecified", account.Attributes.StatusReason)
+	assert.Equal(t, "Jeff Page", account.Attributes.OrganisationIdentification.Actors[0].Name[0])
+	assert.Equal(t, "13YH458762", account.Attributes.PrivateIdentification.Identification)
+	assert.Equal(t, "Some account related value", account.Attributes.UserDefinedData[0].Value)
+	assert.Equal(t, uuid.MustParse("a52d13a4-f435-4c00-cfad-f5e7ac5972df"), account.Relationships.MasterAccount.Data[0].ID)
+
+	// encoding the account again must give back everything the api sent
+	var expected map[string]json.RawMessage
+	err = json.Unmarshal(fixture, &expected)
+	if err != nil {
+		panic(err)
+	}
+	var expectedData map[string]interface{}
+	err = json.Unmarshal(expected["data"], &expectedData)
+	if err != nil {
+		panic(err)
+	}
+	delete(expectedData, "type")
+	expectedJson, _ := json.Marshal(expectedData)
+
+	actualJson, err := json.Marshal(account)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.JSONEq(t, string(expectedJson), string(actualJson))
+}
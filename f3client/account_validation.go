@@ -0,0 +1,355 @@
+package f3client
+
+import (
+	"regexp"
+)
+
+// AccountRule checks a single requirement of an account, it returns nil when the account
+// meets the requirement and an error describing the offending field otherwise
+//
+// Example
+//
+//	noJointAccounts := func(account *f3client.Account) error {
+//		if account.Attributes.JointAccount {
+//			return f3client.NewArgError("joint_account", "joint accounts are not supported")
+//		}
+//		return nil
+//	}
+type AccountRule func(account *Account) error
+
+// AccountValidator checks accounts against a set of rules that apply to every account and
+// sets of rules that only apply to the accounts of a given country
+//
+// The validator returned by NewAccountValidator comes with the per country rules documented by
+// form3, more rules can be added to it with AddRules and AddCountryRules. The mandatory fields
+// and the format of the codes are always checked, whatever the rules of the validator.
+type AccountValidator struct {
+	rules        []AccountRule
+	countryRules map[Country][]AccountRule
+}
+
+// accountRules are checked for every account before the rules of the validator
+var accountRules = []AccountRule{
+	requireCountry,
+	requireName,
+	validCountry,
+	validBaseCurrency,
+	validBankIDCode,
+	validBic,
+	validIban,
+	ibanMatchesCountry,
+}
+
+// NewAccountValidator creates an AccountValidator with the per country rules documented by form3
+func NewAccountValidator() *AccountValidator {
+	v := &AccountValidator{
+		countryRules: map[Country][]AccountRule{},
+	}
+
+	for country, format := range accountFormats {
+		v.AddCountryRules(country, format.rules(country)...)
+	}
+
+	return v
+}
+
+// AddRules adds rules that apply to every account
+func (v *AccountValidator) AddRules(rules ...AccountRule) {
+	v.rules = append(v.rules, rules...)
+}
+
+// AddCountryRules adds rules that only apply to the accounts of the country
+func (v *AccountValidator) AddCountryRules(country Country, rules ...AccountRule) {
+	v.countryRules[country] = append(v.countryRules[country], rules...)
+}
+
+// SetCountryRules replaces the rules of the country, including the built-in ones. Calling it
+// without rules turns off the country specific checks for the country.
+func (v *AccountValidator) SetCountryRules(country Country, rules ...AccountRule) {
+	v.countryRules[country] = rules
+}
+
+// Validate checks the account against all the rules that apply to it, it returns nil when
+// the account is valid and a ValidationError holding every broken rule otherwise
+func (v *AccountValidator) Validate(account *Account) error {
+	return validateAccount(account, v)
+}
+
+// validateAccount checks the account against the rules every account has to meet and, when
+// the validator is not nil, against the rules of the validator
+func validateAccount(account *Account, v *AccountValidator) error {
+	var errs []error
+
+	ruleSets := [][]AccountRule{accountRules}
+	if v != nil {
+		ruleSets = append(ruleSets, v.rules, v.countryRules[account.Attributes.Country])
+	}
+
+	for _, rules := range ruleSets {
+		for _, rule := range rules {
+			if err := rule(account); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+
+	return nil
+}
+
+// defaultAccountValidator is used by Validate and by the clients created without WithAccountValidator
+var defaultAccountValidator = NewAccountValidator()
+
+// Validate checks the account against the built-in rules and returns a ValidationError
+// holding every broken rule, this is the check done by Accounts.Create before sending
+// the request
+//
+// Example
+//
+//	err := f3client.Validate(&account)
+//	var validationErr *f3client.ValidationError
+//	if errors.As(err, &validationErr) {
+//		for _, e := range validationErr.Errors {
+//			log.Println(e)
+//		}
+//	}
+func Validate(account *Account) error {
+	return defaultAccountValidator.Validate(account)
+}
+
+// WithAccountValidator configures f3client.Client to validate accounts with the validator
+// being passed before creating them, a nil validator turns the country specific rules off and
+// only the mandatory fields and the format of the codes are checked
+func WithAccountValidator(validator *AccountValidator) Option {
+	f := func(c *Client) error {
+		c.accountValidator = validator
+		return nil
+	}
+	return f
+}
+
+func requireCountry(account *Account) error {
+	if account.Attributes.Country == "" {
+		return NewArgError("country", "country is mandatory")
+	}
+	return nil
+}
+
+func requireName(account *Account) error {
+	if len(account.Attributes.Name) == 0 {
+		return NewArgError("name", "names are mandatory")
+	}
+	return nil
+}
+
+// the codes of the account attributes are only checked when they are set
+
+func validCountry(account *Account) error {
+	if c := account.Attributes.Country; c != "" && !c.IsValid() {
+		return NewArgError("country", "country must be an ISO 3166-1 alpha-2 code")
+	}
+	return nil
+}
+
+func validBaseCurrency(account *Account) error {
+	if c := account.Attributes.BaseCurrency; c != "" && !c.IsValid() {
+		return NewArgError("base_currency", "base_currency must be an ISO 4217 code")
+	}
+	return nil
+}
+
+func validBankIDCode(account *Account) error {
+	if c := account.Attributes.BankIDCode; c != "" && !c.IsValid() {
+		return NewArgError("bank_id_code", "bank_id_code is not a known bank id code")
+	}
+	return nil
+}
+
+func validBic(account *Account) error {
+	if b := account.Attributes.Bic; b != "" && !b.IsValid() {
+		return NewArgError("bic", "bic is not a valid SWIFT BIC")
+	}
+	return nil
+}
+
+func validIban(account *Account) error {
+	if i := account.Attributes.Iban; i != "" && !i.IsValid() {
+		return NewArgError("iban", "iban is malformed or its checksum does not match")
+	}
+	return nil
+}
+
+func ibanMatchesCountry(account *Account) error {
+	a := account.Attributes
+
+	if a.Iban != "" && a.Country != "" && a.Iban.Country() != a.Country {
+		return NewArgError("iban", "iban must belong to the country of the account")
+	}
+	return nil
+}
+
+// accountFormat describes the fields of the accounts of a country as documented
+// at https://api-docs.form3.tech/api.html#organisation-accounts-create
+type accountFormat struct {
+	// bankID is nil when the country does not use bank ids
+	bankID         *regexp.Regexp
+	bankIDFormat   string
+	bankIDRequired bool
+	bankIDCode     BankIDCode
+	bicRequired    bool
+	accountNumber  *regexp.Regexp
+	accountFormat  string
+	ibanSupported  bool
+}
+
+// rules turns the format into the rules checked for the accounts of the country
+func (f accountFormat) rules(country Country) []AccountRule {
+	suffix := " for " + string(country) + " accounts"
+	rules := []AccountRule{}
+
+	if f.bankID == nil {
+		rules = append(rules, func(account *Account) error {
+			if account.Attributes.BankID != "" {
+				return NewArgError("bank_id", "bank_id is not supported"+suffix)
+			} else if account.Attributes.BankIDCode != "" {
+				return NewArgError("bank_id_code", "bank_id_code is not supported"+suffix)
+			}
+			return nil
+		})
+	} else {
+		rules = append(rules, func(account *Account) error {
+			bankID := account.Attributes.BankID
+			if bankID == "" && f.bankIDRequired {
+				return NewArgError("bank_id", "bank_id is mandatory"+suffix)
+			} else if bankID != "" && !f.bankID.MatchString(bankID) {
+				return NewArgError("bank_id", "bank_id must be "+f.bankIDFormat+suffix)
+			}
+			return nil
+		}, func(account *Account) error {
+			// the code is mandatory as soon as the account has a bank id
+			code := account.Attributes.BankIDCode
+			mandatory := f.bankIDRequired || account.Attributes.BankID != ""
+			if (code == "" && mandatory) || (code != "" && code != f.bankIDCode) {
+				return NewArgError("bank_id_code", "bank_id_code must be "+string(f.bankIDCode)+suffix)
+			}
+			return nil
+		})
+	}
+
+	if f.bicRequired {
+		rules = append(rules, func(account *Account) error {
+			if account.Attributes.Bic == "" {
+				return NewArgError("bic", "bic is mandatory"+suffix)
+			}
+			return nil
+		})
+	}
+
+	rules = append(rules, func(account *Account) error {
+		number := account.Attributes.AccountNumber
+		if number != "" && !f.accountNumber.MatchString(number) {
+			return NewArgError("account_number", "account_number must be "+f.accountFormat+suffix)
+		}
+		return nil
+	})
+
+	if !f.ibanSupported {
+		rules = append(rules, func(account *Account) error {
+			if account.Attributes.Iban != "" {
+				return NewArgError("iban", "iban is not supported"+suffix)
+			}
+			return nil
+		})
+	}
+
+	return rules
+}
+
+// accountFormats holds the formats of the countries documented by form3, the ibans of the
+// countries supporting them are generated by the api when they are not sent
+var accountFormats = map[Country]accountFormat{
+	"GB": {
+		bankID: regexp.MustCompile(`^[0-9]{6}$`), bankIDFormat: "a 6 digit sort code", bankIDRequired: true, bankIDCode: BankIDCodeGB,
+		bicRequired:   true,
+		accountNumber: regexp.MustCompile(`^[0-9]{8}$`), accountFormat: "8 digits",
+		ibanSupported: true,
+	},
+	"AU": {
+		bankID: regexp.MustCompile(`^[0-9]{6}$`), bankIDFormat: "a 6 digit BSB code", bankIDCode: BankIDCodeAU,
+		bicRequired:   true,
+		accountNumber: regexp.MustCompile(`^[1-9][0-9]{5,9}$`), accountFormat: "6 to 10 digits not starting with 0",
+	},
+	"BE": {
+		bankID: regexp.MustCompile(`^[0-9]{3}$`), bankIDFormat: "3 digits", bankIDRequired: true, bankIDCode: BankIDCodeBE,
+		accountNumber: regexp.MustCompile(`^[0-9]{7}$`), accountFormat: "7 digits",
+		ibanSupported: true,
+	},
+	"CA": {
+		bankID: regexp.MustCompile(`^0[0-9]{8}$`), bankIDFormat: "a 9 digit routing number starting with 0", bankIDCode: BankIDCodeCA,
+		bicRequired:   true,
+		accountNumber: regexp.MustCompile(`^[0-9]{7,12}$`), accountFormat: "7 to 12 digits",
+	},
+	"CH": {
+		bankID: regexp.MustCompile(`^[0-9]{5}$`), bankIDFormat: "5 digits", bankIDRequired: true, bankIDCode: BankIDCodeCH,
+		accountNumber: regexp.MustCompile(`^[0-9A-Z]{12}$`), accountFormat: "12 characters",
+		ibanSupported: true,
+	},
+	"DE": {
+		bankID: regexp.MustCompile(`^[0-9]{8}$`), bankIDFormat: "an 8 digit Bankleitzahl", bankIDRequired: true, bankIDCode: BankIDCodeDE,
+		accountNumber: regexp.MustCompile(`^[0-9]{1,10}$`), accountFormat: "up to 10 digits",
+		ibanSupported: true,
+	},
+	"ES": {
+		bankID: regexp.MustCompile(`^[0-9]{8}$`), bankIDFormat: "8 digits", bankIDRequired: true, bankIDCode: BankIDCodeES,
+		accountNumber: regexp.MustCompile(`^[0-9]{10}$`), accountFormat: "10 digits",
+		ibanSupported: true,
+	},
+	"FR": {
+		bankID: regexp.MustCompile(`^[0-9]{10}$`), bankIDFormat: "10 digits", bankIDRequired: true, bankIDCode: BankIDCodeFR,
+		accountNumber: regexp.MustCompile(`^[0-9A-Z]{10}$`), accountFormat: "10 characters",
+		ibanSupported: true,
+	},
+	"GR": {
+		bankID: regexp.MustCompile(`^[0-9]{7}$`), bankIDFormat: "7 digits", bankIDRequired: true, bankIDCode: BankIDCodeGR,
+		accountNumber: regexp.MustCompile(`^[0-9]{16}$`), accountFormat: "16 digits",
+		ibanSupported: true,
+	},
+	"HK": {
+		bankID: regexp.MustCompile(`^[0-9]{3}$`), bankIDFormat: "3 digits", bankIDCode: BankIDCodeHK,
+		bicRequired:   true,
+		accountNumber: regexp.MustCompile(`^[0-9]{9,12}$`), accountFormat: "9 to 12 digits",
+	},
+	"IT": {
+		bankID: regexp.MustCompile(`^[0-9A-Z]?[0-9]{10}$`), bankIDFormat: "10 or 11 characters", bankIDRequired: true, bankIDCode: BankIDCodeIT,
+		accountNumber: regexp.MustCompile(`^[0-9A-Z]{12}$`), accountFormat: "12 characters",
+		ibanSupported: true,
+	},
+	"LU": {
+		bankID: regexp.MustCompile(`^[0-9]{3}$`), bankIDFormat: "3 digits", bankIDRequired: true, bankIDCode: BankIDCodeLU,
+		accountNumber: regexp.MustCompile(`^[0-9A-Z]{13}$`), accountFormat: "13 characters",
+		ibanSupported: true,
+	},
+	"NL": {
+		bicRequired:   true,
+		accountNumber: regexp.MustCompile(`^[0-9]{10}$`), accountFormat: "10 digits",
+		ibanSupported: true,
+	},
+	"PL": {
+		bankID: regexp.MustCompile(`^[0-9]{8}$`), bankIDFormat: "8 digits", bankIDRequired: true, bankIDCode: BankIDCodePL,
+		accountNumber: regexp.MustCompile(`^[0-9]{16}$`), accountFormat: "16 digits",
+		ibanSupported: true,
+	},
+	"PT": {
+		bankID: regexp.MustCompile(`^[0-9]{8}$`), bankIDFormat: "8 digits", bankIDRequired: true, bankIDCode: BankIDCodePT,
+		accountNumber: regexp.MustCompile(`^[0-9]{11}$`), accountFormat: "11 digits",
+		ibanSupported: true,
+	},
+	"US": {
+		bankID: regexp.MustCompile(`^[0-9]{9}$`), bankIDFormat: "a 9 digit ABA routing number", bankIDRequired: true, bankIDCode: BankIDCodeUS,
+		bicRequired:   true,
+		accountNumber: regexp.MustCompile(`^[0-9]{6,17}$`), accountFormat: "6 to 17 digits",
+	},
+}
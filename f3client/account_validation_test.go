@@ -0,0 +1,219 @@
+package f3client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// violations returns the fields reported by the validation error
+func violations(t *testing.T, err error) []string {
+	var validationErr *f3client.ValidationError
+	if !errors.As(err, &validationErr) {
+		assert.FailNow(t, "expected a validation error", "got %v", err)
+	}
+
+	fields := []string{}
+	for _, e := range validationErr.Errors {
+		var argErr *f3client.ArgumentError
+		if errors.As(e, &argErr) {
+			fields = append(fields, argErr.Arg())
+		}
+	}
+	return fields
+}
+
+func newValidationAccount(attributes f3client.AccountAttributes) *f3client.Account {
+	attributes.Name = []string{"Jon Doe"}
+	return &f3client.Account{ID: uuid.New(), OrganisationID: uuid.New(), Attributes: attributes}
+}
+
+func Test_Unit_Validate_ValidAccounts(t *testing.T) {
+	cases := map[string]f3client.AccountAttributes{
+		"GB": {Country: "GB", BankID: "601613", BankIDCode: "GBDSC", Bic: "NWBKGB22", AccountNumber: "31926819", Iban: "GB29NWBK60161331926819"},
+		"DE": {Country: "DE", BankID: "37040044", BankIDCode: "DEBLZ", AccountNumber: "0532013000", Iban: "DE89370400440532013000"},
+		"AU": {Country: "AU", BankID: "062000", BankIDCode: "AUBSB", Bic: "CTBAAU2S", AccountNumber: "12345678"},
+		"CA": {Country: "CA", BankID: "000312345", BankIDCode: "CACPA", Bic: "ROYCCAT2", AccountNumber: "1234567"},
+		"US": {Country: "US", BankID: "021000021", BankIDCode: "USABA", Bic: "CHASUS33", AccountNumber: "123456789"},
+		"NL": {Country: "NL", Bic: "ABNANL2A"},
+		// countries without built-in rules only have to pass the common rules
+		"IN": {Country: "IN", BaseCurrency: "INR"},
+	}
+
+	for name, attributes := range cases {
+		assert.NoError(t, f3client.Validate(newValidationAccount(attributes)), name)
+	}
+}
+
+func Test_Unit_Validate_AllViolations(t *testing.T) {
+	account := newValidationAccount(f3client.AccountAttributes{Country: "GB", BaseCurrency: "GPB", AccountNumber: "1234"})
+
+	err := f3client.Validate(account)
+
+	assert.Equal(t, []string{"base_currency", "bank_id", "bank_id_code", "bic", "account_number"}, violations(t, err))
+	assert.EqualError(t, err, "base_currency : base_currency must be an ISO 4217 code; "+
+		"bank_id : bank_id is mandatory for GB accounts; "+
+		"bank_id_code : bank_id_code must be GBDSC for GB accounts; "+
+		"bic : bic is mandatory for GB accounts; "+
+		"account_number : account_number must be 8 digits for GB accounts")
+
+	// every malformed code is reported
+	account = newValidationAccount(f3client.AccountAttributes{Country: "ZZ", BaseCurrency: "GPB", BankIDCode: "XXX", Bic: "NWBK", Iban: "GB11NWBK40030041426819"})
+	assert.Equal(t, []string{"country", "base_currency", "bank_id_code", "bic", "iban", "iban"}, violations(t, f3client.Validate(account)))
+
+	// the first violation can be matched directly
+	var argErr *f3client.ArgumentError
+	assert.ErrorAs(t, err, &argErr)
+	assert.Equal(t, "base_currency", argErr.Arg())
+}
+
+func Test_Unit_Validate_CountryRules(t *testing.T) {
+	cases := map[string]struct {
+		attributes f3client.AccountAttributes
+		expected   []string
+	}{
+		"GB sort code":        {f3client.AccountAttributes{Country: "GB", BankID: "40030", BankIDCode: "GBDSC", Bic: "NWBKGB22"}, []string{"bank_id"}},
+		"GB bank id code":     {f3client.AccountAttributes{Country: "GB", BankID: "400300", BankIDCode: "DEBLZ", Bic: "NWBKGB22"}, []string{"bank_id_code"}},
+		"DE bankleitzahl":     {f3client.AccountAttributes{Country: "DE", BankID: "3704004", BankIDCode: "DEBLZ"}, []string{"bank_id"}},
+		"DE missing bank id":  {f3client.AccountAttributes{Country: "DE"}, []string{"bank_id", "bank_id_code"}},
+		"DE account number":   {f3client.AccountAttributes{Country: "DE", BankID: "37040044", BankIDCode: "DEBLZ", AccountNumber: "05320130001"}, []string{"account_number"}},
+		"AU bsb code":         {f3client.AccountAttributes{Country: "AU", BankID: "062000", Bic: "CTBAAU2S"}, []string{"bank_id_code"}},
+		"AU account number":   {f3client.AccountAttributes{Country: "AU", Bic: "CTBAAU2S", AccountNumber: "0123456"}, []string{"account_number"}},
+		"CA routing number":   {f3client.AccountAttributes{Country: "CA", BankID: "100312345", BankIDCode: "CACPA", Bic: "ROYCCAT2"}, []string{"bank_id"}},
+		"US iban":             {f3client.AccountAttributes{Country: "US", BankID: "021000021", BankIDCode: "USABA", Bic: "CHASUS33", Iban: "GB29NWBK60161331926819"}, []string{"iban", "iban"}},
+		"NL bank id":          {f3client.AccountAttributes{Country: "NL", Bic: "ABNANL2A", BankID: "123"}, []string{"bank_id"}},
+		"iban of another one": {f3client.AccountAttributes{Country: "DE", BankID: "37040044", BankIDCode: "DEBLZ", Iban: "GB29NWBK60161331926819"}, []string{"iban"}},
+	}
+
+	for name, c := range cases {
+		err := f3client.Validate(newValidationAccount(c.attributes))
+
+		assert.Equal(t, c.expected, violations(t, err), name)
+	}
+}
+
+func Test_Unit_AccountValidator_CustomRules(t *testing.T) {
+	validator := f3client.NewAccountValidator()
+	validator.AddRules(func(account *f3client.Account) error {
+		if account.Attributes.JointAccount {
+			return f3client.NewArgError("joint_account", "joint accounts are not supported")
+		}
+		return nil
+	})
+	validator.AddCountryRules("IN", func(account *f3client.Account) error {
+		if account.Attributes.BaseCurrency != "INR" {
+			return f3client.NewArgError("base_currency", "base_currency must be INR for IN accounts")
+		}
+		return nil
+	})
+	validator.SetCountryRules("GB")
+
+	account := newValidationAccount(f3client.AccountAttributes{Country: "IN", BaseCurrency: "GBP", JointAccount: true})
+	assert.Equal(t, []string{"joint_account", "base_currency"}, violations(t, validator.Validate(account)))
+
+	// the built-in GB rules are turned off
+	account = newValidationAccount(f3client.AccountAttributes{Country: "GB"})
+	assert.NoError(t, validator.Validate(account))
+
+	// the default validator is left as it was
+	assert.Error(t, f3client.Validate(account))
+}
+
+func Test_Unit_AccountService_Create_ValidationFailed(t *testing.T) {
+	var calls int
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	account := newValidationAccount(f3client.AccountAttributes{Country: "DE", Iban: "DE89370400440532013001"})
+	err = client.Accounts.Create(context.Background(), account)
+
+	assert.Equal(t, []string{"iban", "bank_id", "bank_id_code"}, violations(t, err))
+	assert.Equal(t, 0, calls)
+}
+
+func Test_Unit_AccountService_Create_WithAccountValidator(t *testing.T) {
+	var calls int
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "organisation_id": "ee2fb143-6dfe-4787-b183-de8ddd4164d1", "type": "accounts", "attributes": {"country": "GB", "name": ["Jon Doe"]}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	strict := f3client.NewAccountValidator()
+	strict.AddCountryRules("GB", func(account *f3client.Account) error {
+		if account.Attributes.AccountNumber == "" {
+			return f3client.NewArgError("account_number", "account_number is mandatory")
+		}
+		return nil
+	})
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithAccountValidator(strict))
+	if err != nil {
+		panic(err)
+	}
+
+	account := newValidationAccount(f3client.AccountAttributes{Country: "GB", BankID: "400300", BankIDCode: "GBDSC", Bic: "NWBKGB22"})
+	err = client.Accounts.Create(context.Background(), account)
+
+	assert.Equal(t, []string{"account_number"}, violations(t, err))
+	assert.Equal(t, 0, calls)
+
+	// a nil validator turns the country specific rules off
+	client, err = f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithAccountValidator(nil))
+	if err != nil {
+		panic(err)
+	}
+
+	err = client.Accounts.Create(context.Background(), newValidationAccount(f3client.AccountAttributes{Country: "GB"}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	// the codes are still checked without a validator
+	err = client.Accounts.Create(context.Background(), newValidationAccount(f3client.AccountAttributes{Country: "ZZ", Iban: "GB11NWBK40030041426819"}))
+
+	assert.Equal(t, []string{"country", "iban", "iban"}, violations(t, err))
+	assert.Equal(t, 1, calls)
+}
+
+func Test_Unit_AccountService_Create_MissingFields(t *testing.T) {
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Fail(t, "no request expected")
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	account := &f3client.Account{ID: uuid.New(), OrganisationID: uuid.New()}
+	err = client.Accounts.Create(context.Background(), account)
+
+	assert.Equal(t, []string{"country", "name"}, violations(t, err))
+}
@@ -0,0 +1,242 @@
+package f3client
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type ConfirmationOfPayeeService struct {
+	service
+	ObjectType string
+}
+
+// CopAccountType is the type of the account the name is checked against
+type CopAccountType string
+
+// Account types supported by confirmation of payee
+const (
+	CopAccountTypePersonal CopAccountType = "personal"
+	CopAccountTypeBusiness CopAccountType = "business"
+)
+
+// IsValid reports whether the account type is one of the known account types
+func (t CopAccountType) IsValid() bool {
+	return t == CopAccountTypePersonal || t == CopAccountTypeBusiness
+}
+
+// CopMatchResult is the outcome of the name check
+type CopMatchResult string
+
+// Outcomes of a confirmation of payee request
+const (
+	CopFullMatch  CopMatchResult = "full_match"
+	CopCloseMatch CopMatchResult = "close_match"
+	CopNoMatch    CopMatchResult = "no_match"
+)
+
+// CopReasonCode explains why the name check did not result in a full match
+type CopReasonCode string
+
+// Reason codes defined by the confirmation of payee scheme
+const (
+	// CopReasonNameNoMatch is returned when the name does not match the account
+	CopReasonNameNoMatch CopReasonCode = "ANNM"
+	// CopReasonCloseMatch is returned when the name is close to the name of the account
+	CopReasonCloseMatch CopReasonCode = "MBAM"
+	// CopReasonBusinessAccountNameMatch is returned when the name matches but the account is a business account
+	CopReasonBusinessAccountNameMatch CopReasonCode = "BANM"
+	// CopReasonPersonalAccountNameMatch is returned when the name matches but the account is a personal account
+	CopReasonPersonalAccountNameMatch CopReasonCode = "PANM"
+	// CopReasonBusinessAccountCloseMatch is returned when the name is close and the account is a business account
+	CopReasonBusinessAccountCloseMatch CopReasonCode = "BAMM"
+	// CopReasonPersonalAccountCloseMatch is returned when the name is close and the account is a personal account
+	CopReasonPersonalAccountCloseMatch CopReasonCode = "PAMM"
+	CopReasonAccountDoesNotExist       CopReasonCode = "AC01"
+	CopReasonInvalidSecondaryReference CopReasonCode = "IVCR"
+	CopReasonAccountNotSupported       CopReasonCode = "ACNS"
+	CopReasonOptedOut                  CopReasonCode = "OPTO"
+	CopReasonAccountSwitched           CopReasonCode = "CASS"
+	CopReasonSortCodeNotSupported      CopReasonCode = "SCNS"
+)
+
+// CopRequest represents a confirmation of payee request, i.e. a check that the name of a
+// beneficiary matches the name of the uk account it is going to be paid into.
+//
+// See the Confirmation of Payee section of https://api-docs.form3.tech/api.html for
+// more information about fields.
+type CopRequest struct {
+	ID             uuid.UUID            `json:"id,omitempty"`
+	Version        int                  `json:"version,omitempty"`
+	OrganisationID uuid.UUID            `json:"organisation_id,omitempty"`
+	CreatedOn      string               `json:"created_on,omitempty"`
+	ModifiedOn     string               `json:"modified_on,omitempty"`
+	Attributes     CopRequestAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (r *CopRequest) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, r, "type")
+}
+
+func (r CopRequest) MarshalJSON() ([]byte, error) {
+	return marshalExtra(r)
+}
+
+type CopRequestAttributes struct {
+	AccountNumber string `json:"account_number,omitempty"`
+	// BankID is the sort code of the account
+	BankID                  string         `json:"bank_id,omitempty"`
+	BankIDCode              BankIDCode     `json:"bank_id_code,omitempty"`
+	Name                    string         `json:"name,omitempty"`
+	AccountType             CopAccountType `json:"account_type,omitempty"`
+	SecondaryIdentification string         `json:"secondary_identification,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (cra *CopRequestAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, cra)
+}
+
+func (cra CopRequestAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(cra)
+}
+
+// CopResponse represents the outcome of a confirmation of payee request
+type CopResponse struct {
+	ID             uuid.UUID             `json:"id,omitempty"`
+	Version        int                   `json:"version,omitempty"`
+	OrganisationID uuid.UUID             `json:"organisation_id,omitempty"`
+	CreatedOn      string                `json:"created_on,omitempty"`
+	ModifiedOn     string                `json:"modified_on,omitempty"`
+	Attributes     CopResponseAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (r *CopResponse) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, r, "type")
+}
+
+func (r CopResponse) MarshalJSON() ([]byte, error) {
+	return marshalExtra(r)
+}
+
+type CopResponseAttributes struct {
+	Result     CopMatchResult `json:"result,omitempty"`
+	ReasonCode CopReasonCode  `json:"reason_code,omitempty"`
+	// SuggestedName is the name of the account holder, only sent back for a close match
+	SuggestedName string `json:"suggested_name,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (cra *CopResponseAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, cra)
+}
+
+func (cra CopResponseAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(cra)
+}
+
+// IsFullMatch reports whether the name matches the account exactly
+func (r *CopResponse) IsFullMatch() bool {
+	return r.Attributes.Result == CopFullMatch
+}
+
+// validate checks the request for the fields required by the scheme, the sort code and the account
+// number must have the format of GB accounts. A ValidationError holding every broken rule is returned.
+func (r *CopRequest) validate() error {
+	var errs []error
+
+	gb := accountFormats["GB"]
+	a := r.Attributes
+
+	if !gb.accountNumber.MatchString(a.AccountNumber) {
+		errs = append(errs, NewArgError("account_number", "account_number must be "+gb.accountFormat+" for confirmation of payee request"))
+	}
+	if !gb.bankID.MatchString(a.BankID) {
+		errs = append(errs, NewArgError("bank_id", "bank_id must be "+gb.bankIDFormat+" for confirmation of payee request"))
+	}
+	if a.BankIDCode != "" && a.BankIDCode != gb.bankIDCode {
+		errs = append(errs, NewArgError("bank_id_code", "bank_id_code must be "+string(gb.bankIDCode)+" for confirmation of payee request"))
+	}
+	if strings.TrimSpace(a.Name) == "" {
+		errs = append(errs, NewArgError("name", "name is mandatory for confirmation of payee request"))
+	}
+	if !a.AccountType.IsValid() {
+		errs = append(errs, NewArgError("account_type", "account_type must be personal or business"))
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+
+	return nil
+}
+
+// Check sends the confirmation of payee request and returns the outcome of the name check
+func (cs *ConfirmationOfPayeeService) Check(ctx context.Context, request *CopRequest) (*CopResponse, error) {
+	// validate for mandatory request fields before creating new request
+	err := request.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := cs.client.NewRequest(ctx, Post, "/v1/confirmation-of-payee/requests", cs.ObjectType, request)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := cs.client.SendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	} else if resp == nil {
+		return nil, errors.New("api returned no content for confirmation of payee request")
+	}
+
+	response := new(CopResponse)
+	err = resp.ConvertTo(response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// CheckAccount runs confirmation of payee for an existing uk account
+//
+// The name checked is the account holder name, i.e. the names of the account joined by a space.
+// The account type is taken from the account classification and defaults to personal.
+func (cs *ConfirmationOfPayeeService) CheckAccount(ctx context.Context, account *Account) (*CopResponse, error) {
+	if account.Attributes.Country != "" && account.Attributes.Country != "GB" {
+		return nil, NewArgError("country", "confirmation of payee is only supported for GB accounts")
+	}
+
+	return cs.Check(ctx, NewCopRequest(account))
+}
+
+// NewCopRequest builds a confirmation of payee request with a new id for the account
+func NewCopRequest(account *Account) *CopRequest {
+	accountType := CopAccountTypePersonal
+	if strings.EqualFold(account.Attributes.AccountClassification, "business") {
+		accountType = CopAccountTypeBusiness
+	}
+
+	return &CopRequest{
+		ID:             uuid.New(),
+		OrganisationID: account.OrganisationID,
+		Attributes: CopRequestAttributes{
+			AccountNumber:           account.Attributes.AccountNumber,
+			BankID:                  account.Attributes.BankID,
+			BankIDCode:              account.Attributes.BankIDCode,
+			Name:                    strings.Join(account.Attributes.Name, " "),
+			AccountType:             accountType,
+			SecondaryIdentification: account.Attributes.SecondaryIdentification,
+		},
+	}
+}
@@ -0,0 +1,181 @@
+package f3client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unit_ConfirmationOfPayeeService_Check(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "3d4e5f6a-7b8c-4d9e-8f0a-1b2c3d4e5f6a", "type": "cop_requests",
+			"attributes": {"result": "close_match", "reason_code": "MBAM", "suggested_name": "Jane Doe"}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	request := &f3client.CopRequest{
+		ID:             uuid.New(),
+		OrganisationID: uuid.New(),
+		Attributes: f3client.CopRequestAttributes{
+			AccountNumber: "41426819",
+			BankID:        "400300",
+			Name:          "Jane Do",
+			AccountType:   f3client.CopAccountTypePersonal,
+		},
+	}
+
+	response, err := client.ConfirmationOfPayee.Check(context.Background(), request)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	attributes := actualBody["data"]["attributes"].(map[string]interface{})
+	assert.Equal(t, "/v1/confirmation-of-payee/requests", actualPath)
+	assert.Equal(t, "cop_requests", actualBody["data"]["type"])
+	assert.Equal(t, "personal", attributes["account_type"])
+
+	assert.False(t, response.IsFullMatch())
+	assert.Equal(t, f3client.CopCloseMatch, response.Attributes.Result)
+	assert.Equal(t, f3client.CopReasonCloseMatch, response.Attributes.ReasonCode)
+	assert.Equal(t, "Jane Doe", response.Attributes.SuggestedName)
+}
+
+func Test_Unit_ConfirmationOfPayeeService_Check_Invalid(t *testing.T) {
+	var calls int
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	valid := f3client.CopRequestAttributes{AccountNumber: "41426819", BankID: "400300", Name: "Jane Doe", AccountType: f3client.CopAccountTypeBusiness}
+
+	cases := map[string]func(a *f3client.CopRequestAttributes){
+		"short account number":  func(a *f3client.CopRequestAttributes) { a.AccountNumber = "1234" },
+		"sort code with dashes": func(a *f3client.CopRequestAttributes) { a.BankID = "40-03-00" },
+		"not a sort code":       func(a *f3client.CopRequestAttributes) { a.BankIDCode = "DEBLZ" },
+		"blank name":            func(a *f3client.CopRequestAttributes) { a.Name = "  " },
+		"unknown account type":  func(a *f3client.CopRequestAttributes) { a.AccountType = "joint" },
+	}
+
+	for name, change := range cases {
+		attributes := valid
+		change(&attributes)
+
+		_, err = client.ConfirmationOfPayee.Check(context.Background(), &f3client.CopRequest{ID: uuid.New(), OrganisationID: uuid.New(), Attributes: attributes})
+
+		var targetErr *f3client.ArgumentError
+		assert.ErrorAs(t, err, &targetErr, name)
+	}
+
+	assert.Equal(t, 0, calls)
+}
+
+func Test_Unit_ConfirmationOfPayeeService_Check_AllViolations(t *testing.T) {
+	client, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	request := &f3client.CopRequest{
+		ID:         uuid.New(),
+		Attributes: f3client.CopRequestAttributes{AccountNumber: "1234", BankID: "40-03-00", Name: "Jane Doe"},
+	}
+
+	_, err = client.ConfirmationOfPayee.Check(context.Background(), request)
+
+	assert.Equal(t, []string{"account_number", "bank_id", "account_type"}, violations(t, err))
+	assert.EqualError(t, err, "account_number : account_number must be 8 digits for confirmation of payee request; "+
+		"bank_id : bank_id must be a 6 digit sort code for confirmation of payee request; "+
+		"account_type : account_type must be personal or business")
+}
+
+func Test_Unit_ConfirmationOfPayeeService_CheckAccount(t *testing.T) {
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "3d4e5f6a-7b8c-4d9e-8f0a-1b2c3d4e5f6a", "type": "cop_requests", "attributes": {"result": "full_match"}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	account := &f3client.Account{
+		ID:             uuid.New(),
+		OrganisationID: uuid.New(),
+		Attributes: f3client.AccountAttributes{
+			Country:               "GB",
+			BankID:                "400300",
+			BankIDCode:            "GBDSC",
+			AccountNumber:         "41426819",
+			Name:                  []string{"Acme", "Trading Ltd"},
+			AccountClassification: "Business",
+		},
+	}
+
+	response, err := client.ConfirmationOfPayee.CheckAccount(context.Background(), account)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	attributes := actualBody["data"]["attributes"].(map[string]interface{})
+	assert.Equal(t, account.OrganisationID.String(), actualBody["data"]["organisation_id"])
+	assert.Equal(t, "Acme Trading Ltd", attributes["name"])
+	assert.Equal(t, "business", attributes["account_type"])
+	assert.Equal(t, "41426819", attributes["account_number"])
+	assert.True(t, response.IsFullMatch())
+}
+
+func Test_Unit_ConfirmationOfPayeeService_CheckAccount_NotGB(t *testing.T) {
+	client, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	account := &f3client.Account{
+		ID:             uuid.New(),
+		OrganisationID: uuid.New(),
+		Attributes:     f3client.AccountAttributes{Country: "DE", BankID: "37040044", Name: []string{"Jane Doe"}},
+	}
+
+	_, err = client.ConfirmationOfPayee.CheckAccount(context.Background(), account)
+
+	var targetErr *f3client.ArgumentError
+	assert.ErrorAs(t, err, &targetErr)
+}
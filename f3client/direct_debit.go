@@ -0,0 +1,232 @@
+package f3client
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type DirectDebitService struct {
+	service
+	ObjectType string
+}
+
+// DirectDebit represents a collection made from the account of a debtor under a mandate.
+//
+// See the Direct Debits section of https://api-docs.form3.tech/api.html for
+// more information about fields.
+type DirectDebit struct {
+	ID             uuid.UUID             `json:"id,omitempty"`
+	Version        int                   `json:"version,omitempty"`
+	OrganisationID uuid.UUID             `json:"organisation_id,omitempty"`
+	CreatedOn      string                `json:"created_on,omitempty"`
+	ModifiedOn     string                `json:"modified_on,omitempty"`
+	Attributes     DirectDebitAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (dd *DirectDebit) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, dd, "type")
+}
+
+func (dd DirectDebit) MarshalJSON() ([]byte, error) {
+	return marshalExtra(dd)
+}
+
+type DirectDebitAttributes struct {
+	Amount            string          `json:"amount,omitempty"`
+	Currency          string          `json:"currency,omitempty"`
+	BeneficiaryParty  *PaymentParty   `json:"beneficiary_party,omitempty"`
+	DebtorParty       *PaymentParty   `json:"debtor_party,omitempty"`
+	EndToEndReference string          `json:"end_to_end_reference,omitempty"`
+	MandateReference  string          `json:"mandate_reference,omitempty"`
+	PaymentScheme     string          `json:"payment_scheme,omitempty"`
+	ProcessingDate    string          `json:"processing_date,omitempty"`
+	Reference         string          `json:"reference,omitempty"`
+	SchemePaymentType string          `json:"scheme_payment_type,omitempty"`
+	Status            string          `json:"status,omitempty"`
+	Bacs              *BacsSchemeData `json:"bacs,omitempty"`
+	Sepa              *SepaSchemeData `json:"sepa,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (dda *DirectDebitAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, dda)
+}
+
+func (dda DirectDebitAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(dda)
+}
+
+// DirectDebitDecision represents the decision taken on an inbound direct debit, i.e. whether
+// the collection is paid or rejected
+type DirectDebitDecision struct {
+	ID             uuid.UUID                     `json:"id,omitempty"`
+	Version        int                           `json:"version,omitempty"`
+	OrganisationID uuid.UUID                     `json:"organisation_id,omitempty"`
+	CreatedOn      string                        `json:"created_on,omitempty"`
+	ModifiedOn     string                        `json:"modified_on,omitempty"`
+	Attributes     DirectDebitDecisionAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ddd *DirectDebitDecision) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ddd, "type")
+}
+
+func (ddd DirectDebitDecision) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ddd)
+}
+
+type DirectDebitDecisionAttributes struct {
+	Answer       string `json:"answer,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+	Status       string `json:"status,omitempty"`
+	StatusReason string `json:"status_reason,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ddda *DirectDebitDecisionAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ddda)
+}
+
+func (ddda DirectDebitDecisionAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ddda)
+}
+
+// DirectDebitReturn represents the return of a direct debit that has been collected
+type DirectDebitReturn struct {
+	ID             uuid.UUID        `json:"id,omitempty"`
+	Version        int              `json:"version,omitempty"`
+	OrganisationID uuid.UUID        `json:"organisation_id,omitempty"`
+	CreatedOn      string           `json:"created_on,omitempty"`
+	ModifiedOn     string           `json:"modified_on,omitempty"`
+	Attributes     ReturnAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ddr *DirectDebitReturn) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ddr, "type")
+}
+
+func (ddr DirectDebitReturn) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ddr)
+}
+
+// DirectDebitReversal represents the reversal of a direct debit that has been collected
+type DirectDebitReversal struct {
+	ID             uuid.UUID          `json:"id,omitempty"`
+	Version        int                `json:"version,omitempty"`
+	OrganisationID uuid.UUID          `json:"organisation_id,omitempty"`
+	CreatedOn      string             `json:"created_on,omitempty"`
+	ModifiedOn     string             `json:"modified_on,omitempty"`
+	Attributes     ReversalAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ddr *DirectDebitReversal) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ddr, "type")
+}
+
+func (ddr DirectDebitReversal) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ddr)
+}
+
+// directDebitPath returns the path of a direct debit, the sub resources of the direct debit live under it
+func directDebitPath(directDebitId uuid.UUID) string {
+	return "/v1/transaction/directdebits/" + directDebitId.String()
+}
+
+// Fetch gets the direct debit with the id, example the one carried by a notification about an
+// incoming collection. IsNotFound reports true for the returned error when it does not exist.
+func (ds *DirectDebitService) Fetch(ctx context.Context, directDebitId uuid.UUID) (*DirectDebit, error) {
+	directDebit := new(DirectDebit)
+
+	err := ds.fetch(ctx, directDebitPath(directDebitId), ds.ObjectType, directDebit)
+	if err != nil {
+		return nil, err
+	}
+
+	return directDebit, nil
+}
+
+// List gets a single page of form3 direct debit objects, when opts is nil the first
+// page with the api default page size is returned
+func (ds *DirectDebitService) List(ctx context.Context, opts *ListOptions) ([]DirectDebit, Links, error) {
+	directDebits := []DirectDebit{}
+
+	links, err := ds.list(ctx, addQuery("/v1/transaction/directdebits", opts.values()), ds.ObjectType, &directDebits)
+	if err != nil {
+		return nil, Links{}, err
+	}
+
+	return directDebits, links, nil
+}
+
+// CreateDecision records the decision taken on an inbound direct debit, the decision is updated
+// in place with the decision sent back by the api
+func (ds *DirectDebitService) CreateDecision(ctx context.Context, directDebitId uuid.UUID, decision *DirectDebitDecision) error {
+	// validate for mandatory decision fields before creating new request
+	if decision.Attributes.Answer == "" {
+		return NewArgError("answer", "answer is mandatory for direct debit decision create request")
+	}
+
+	return ds.create(ctx, directDebitPath(directDebitId)+"/decisions", "directdebit_decisions", decision.ID, decision)
+}
+
+// FetchDecision gets the decision taken on a direct debit
+func (ds *DirectDebitService) FetchDecision(ctx context.Context, directDebitId, decisionId uuid.UUID) (*DirectDebitDecision, error) {
+	decision := new(DirectDebitDecision)
+
+	err := ds.fetch(ctx, directDebitPath(directDebitId)+"/decisions/"+decisionId.String(), "directdebit_decisions", decision)
+	if err != nil {
+		return nil, err
+	}
+
+	return decision, nil
+}
+
+// CreateReturn returns a direct debit, the return is updated in place with the return sent back by the api
+func (ds *DirectDebitService) CreateReturn(ctx context.Context, directDebitId uuid.UUID, ret *DirectDebitReturn) error {
+	// validate for mandatory return fields before creating new request
+	if ret.Attributes.ReturnCode == "" {
+		return NewArgError("return_code", "return_code is mandatory for return create request")
+	}
+
+	return ds.create(ctx, directDebitPath(directDebitId)+"/returns", "directdebit_returns", ret.ID, ret)
+}
+
+// FetchReturn gets a return of a direct debit
+func (ds *DirectDebitService) FetchReturn(ctx context.Context, directDebitId, returnId uuid.UUID) (*DirectDebitReturn, error) {
+	ret := new(DirectDebitReturn)
+
+	err := ds.fetch(ctx, directDebitPath(directDebitId)+"/returns/"+returnId.String(), "directdebit_returns", ret)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// CreateReversal reverses a direct debit, the reversal is updated in place with the reversal sent back by the api
+func (ds *DirectDebitService) CreateReversal(ctx context.Context, directDebitId uuid.UUID, reversal *DirectDebitReversal) error {
+	return ds.create(ctx, directDebitPath(directDebitId)+"/reversals", "directdebit_reversals", reversal.ID, reversal)
+}
+
+// FetchReversal gets a reversal of a direct debit
+func (ds *DirectDebitService) FetchReversal(ctx context.Context, directDebitId, reversalId uuid.UUID) (*DirectDebitReversal, error) {
+	reversal := new(DirectDebitReversal)
+
+	err := ds.fetch(ctx, directDebitPath(directDebitId)+"/reversals/"+reversalId.String(), "directdebit_reversals", reversal)
+	if err != nil {
+		return nil, err
+	}
+
+	return reversal, nil
+}
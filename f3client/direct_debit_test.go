@@ -0,0 +1,177 @@
+package f3client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unit_DirectDebitService_Fetch(t *testing.T) {
+	var actualPath string
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		w.Write([]byte(`{"data": {"id": "c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f", "type": "directdebits",
+			"attributes": {"amount": "45.00", "currency": "GBP", "payment_scheme": "BACS", "bacs": {"service_user_number": "112238", "transaction_code": "17"}}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	directDebitUUID, _ := uuid.Parse("c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f")
+
+	directDebit, err := client.DirectDebits.Fetch(context.Background(), directDebitUUID)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/directdebits/c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f", actualPath)
+	assert.Equal(t, "45.00", directDebit.Attributes.Amount)
+	assert.Equal(t, "112238", directDebit.Attributes.Bacs.ServiceUserNumber)
+}
+
+func Test_Unit_DirectDebitService_List(t *testing.T) {
+	var actualURL string
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualURL = r.URL.String()
+		w.Write([]byte(`{"data": [{"id": "c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f", "type": "directdebits",
+			"attributes": {"payment_scheme": "SEPADD", "sepa": {"creditor_id": "DE98ZZZ09999999999", "sequence_type": "RCUR"}}}]}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	directDebits, _, err := client.DirectDebits.List(context.Background(), &f3client.ListOptions{PageNumber: 1})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/directdebits?page%5Bnumber%5D=1", actualURL)
+	if assert.Len(t, directDebits, 1) {
+		assert.Equal(t, f3client.SequenceTypeRecurring, directDebits[0].Attributes.Sepa.SequenceType)
+	}
+}
+
+func Test_Unit_DirectDebitService_CreateDecision(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "d4e5f6a7-b8c9-4d0e-9f1a-2b3c4d5e6f7a", "type": "directdebit_decisions", "attributes": {"answer": "rejected", "reason": "AC04"}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	directDebitUUID, _ := uuid.Parse("c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f")
+
+	decision := &f3client.DirectDebitDecision{
+		ID:             uuid.New(),
+		OrganisationID: uuid.New(),
+		Attributes:     f3client.DirectDebitDecisionAttributes{Answer: "rejected", Reason: "AC04"},
+	}
+
+	err = client.DirectDebits.CreateDecision(context.Background(), directDebitUUID, decision)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/directdebits/c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f/decisions", actualPath)
+	assert.Equal(t, "directdebit_decisions", actualBody["data"]["type"])
+	assert.Equal(t, "AC04", decision.Attributes.Reason)
+}
+
+func Test_Unit_DirectDebitService_CreateReturn(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "e5f6a7b8-c9d0-4e1f-8a2b-3c4d5e6f7a8b", "type": "directdebit_returns", "attributes": {"return_code": "0", "amount": "45.00"}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	directDebitUUID, _ := uuid.Parse("c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f")
+
+	ret := &f3client.DirectDebitReturn{
+		ID:             uuid.New(),
+		OrganisationID: uuid.New(),
+		Attributes:     f3client.ReturnAttributes{ReturnCode: "0"},
+	}
+
+	err = client.DirectDebits.CreateReturn(context.Background(), directDebitUUID, ret)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/directdebits/c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f/returns", actualPath)
+	assert.Equal(t, "directdebit_returns", actualBody["data"]["type"])
+	assert.Equal(t, "45.00", ret.Attributes.Amount)
+}
+
+func Test_Unit_DirectDebitService_FetchReversal(t *testing.T) {
+	var actualPath string
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		w.Write([]byte(`{"data": {"id": "f6a7b8c9-d0e1-4f2a-9b3c-4d5e6f7a8b9c", "type": "directdebit_reversals", "attributes": {"description": "Collected in error"}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	directDebitUUID, _ := uuid.Parse("c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f")
+	reversalUUID, _ := uuid.Parse("f6a7b8c9-d0e1-4f2a-9b3c-4d5e6f7a8b9c")
+
+	reversal, err := client.DirectDebits.FetchReversal(context.Background(), directDebitUUID, reversalUUID)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/directdebits/c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f/reversals/f6a7b8c9-d0e1-4f2a-9b3c-4d5e6f7a8b9c", actualPath)
+	assert.Equal(t, "Collected in error", reversal.Attributes.Description)
+}
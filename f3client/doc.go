@@ -1,5 +1,8 @@
 // This a library that integrates with the form3 public apis to give a simple iterface
-// Right now this only has support for the account api (Featch, Create, Delete methods only)
+// Right now this has support for the account api (Featch, Create, List, Update, Delete methods) and
+// the payment api (Create, Fetch, List methods, submissions, returns, reversals and recalls),
+// the mandate api, the direct debit api, the subscription api, the organisation api, the
+// security apis (users, roles and aces) and confirmation of payee
 //
 // For more details on the usage of each individual methods look at the examples (example_test.go)
 package f3client
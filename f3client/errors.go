@@ -1,7 +1,13 @@
 package f3client
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
 )
 
 // ArgumentError is raised when the called of a function in this library misses
@@ -25,3 +31,184 @@ func NewArgError(missingArg string, validationMsg string) *ArgumentError {
 func (ae *ArgumentError) Error() string {
 	return fmt.Sprintf("%s : %s", ae.arg, ae.message)
 }
+
+// Arg returns the name of the argument or field that failed the validation
+func (ae *ArgumentError) Arg() string {
+	return ae.arg
+}
+
+// ValidationError is returned when a resource breaks one or more validation rules,
+// it holds an error for every broken rule
+type ValidationError struct {
+	Errors []error
+}
+
+func (ve *ValidationError) Error() string {
+	messages := make([]string, 0, len(ve.Errors))
+	for _, err := range ve.Errors {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the first broken rule, so that errors.As finds the ArgumentError of the first
+// invalid field. The other errors are only available through Errors.
+func (ve *ValidationError) Unwrap() error {
+	if len(ve.Errors) == 0 {
+		return nil
+	}
+	return ve.Errors[0]
+}
+
+// Sentinel errors that an APIError can be matched against using errors.Is
+//
+// Example
+//
+//	if errors.Is(err, f3client.ErrNotFound) {
+//		// handle missing resource
+//	}
+var (
+	ErrNotFound        = errors.New("form3 resource not found")
+	ErrConflict        = errors.New("form3 resource conflict")
+	ErrVersionMismatch = errors.New("form3 resource version mismatch")
+	ErrRateLimited     = errors.New("form3 rate limit exceeded")
+	ErrServerError     = errors.New("form3 server error")
+)
+
+// APIError is returned when the form3 api responds with a 4xx or 5xx status code
+//
+// It carries the error_code and error_message sent back by the api along with the
+// details of the request that failed. When the api (or a proxy in front of it) sends back
+// a body that is not json, Code and Message are empty and the body is available in Body.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Method     string
+	URL        string
+	Body       []byte
+	Header     http.Header
+
+	// Attempts is the number of times the request was sent before giving up
+	Attempts int
+}
+
+// newAPIError builds an APIError from the failed http response and its body
+func newAPIError(httpResp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: httpResp.StatusCode,
+		Body:       body,
+		Header:     httpResp.Header,
+	}
+
+	if httpResp.Request != nil {
+		apiErr.Method = httpResp.Request.Method
+		if httpResp.Request.URL != nil {
+			apiErr.URL = httpResp.Request.URL.String()
+		}
+	}
+
+	errorResponse := new(struct {
+		Code    string `json:"error_code,omitempty"`
+		Message string `json:"error_message,omitempty"`
+	})
+
+	// non json bodies are kept only as raw bytes
+	if json.Unmarshal(body, errorResponse) == nil {
+		apiErr.Code = errorResponse.Code
+		apiErr.Message = errorResponse.Message
+	}
+
+	return apiErr
+}
+
+// Error returns the error message sent back by the api, if the api did not send
+// any message then a message is built from the request and the status code
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("%s %s : %d %s", e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Is reports whether the APIError matches one of the sentinel errors of this package
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrVersionMismatch:
+		return e.StatusCode == http.StatusConflict && strings.Contains(strings.ToLower(e.Message), "version")
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= 500
+	}
+
+	return false
+}
+
+// VersionConflictError is returned when a resource could not be changed because the version
+// sent in the request is not the current version of the resource. The resource has to be
+// fetched again and the change applied to the latest version.
+type VersionConflictError struct {
+	ID      uuid.UUID
+	Version int
+	Err     *APIError
+}
+
+func (ve *VersionConflictError) Error() string {
+	return fmt.Sprintf("version %d of %s is not the current version : %s", ve.Version, ve.ID.String(), ve.Err.Error())
+}
+
+func (ve *VersionConflictError) Unwrap() error {
+	return ve.Err
+}
+
+// contextError is returned when a request fails after its context is done. It
+// unwraps to the failure of the request and matches the context error with errors.Is,
+// so that both can be inspected by the caller.
+type contextError struct {
+	err    error
+	ctxErr error
+}
+
+func (ce *contextError) Error() string {
+	return fmt.Sprintf("%s : %s", ce.err.Error(), ce.ctxErr.Error())
+}
+
+func (ce *contextError) Unwrap() error {
+	return ce.err
+}
+
+func (ce *contextError) Is(target error) bool {
+	return target == ce.ctxErr
+}
+
+// IsNotFound returns true if err is an APIError for a 404 response
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict returns true if err is an APIError for a 409 response
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsVersionMismatch returns true if err is an APIError for a 409 response caused
+// by the resource version sent in the request not matching the current version
+func IsVersionMismatch(err error) bool {
+	return errors.Is(err, ErrVersionMismatch)
+}
+
+// IsRateLimited returns true if err is an APIError for a 429 response
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsServerError returns true if err is an APIError for any 5xx response
+func IsServerError(err error) bool {
+	return errors.Is(err, ErrServerError)
+}
@@ -1,6 +1,8 @@
 package f3client_test
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/benjaminmishra/form3-client-go/v1/f3client"
@@ -17,3 +19,47 @@ func Test_Unit_NewArgError(t *testing.T) {
 	}
 
 }
+
+func Test_Unit_APIError_Is(t *testing.T) {
+	tests := []struct {
+		name     string
+		apiErr   *f3client.APIError
+		target   error
+		expected bool
+	}{
+		{"not found", &f3client.APIError{StatusCode: 404}, f3client.ErrNotFound, true},
+		{"conflict", &f3client.APIError{StatusCode: 409}, f3client.ErrConflict, true},
+		{"version mismatch", &f3client.APIError{StatusCode: 409, Message: "Specified version incorrect"}, f3client.ErrVersionMismatch, true},
+		{"conflict without version", &f3client.APIError{StatusCode: 409, Message: "Account cannot be created as it violates a duplicate constraint"}, f3client.ErrVersionMismatch, false},
+		{"rate limited", &f3client.APIError{StatusCode: 429}, f3client.ErrRateLimited, true},
+		{"server error 500", &f3client.APIError{StatusCode: 500}, f3client.ErrServerError, true},
+		{"server error 599", &f3client.APIError{StatusCode: 599}, f3client.ErrServerError, true},
+		{"bad request is not server error", &f3client.APIError{StatusCode: 400}, f3client.ErrServerError, false},
+		{"not found is not conflict", &f3client.APIError{StatusCode: 404}, f3client.ErrConflict, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, errors.Is(tc.apiErr, tc.target))
+		})
+	}
+}
+
+func Test_Unit_APIError_Helpers(t *testing.T) {
+	wrapped := fmt.Errorf("fetching account : %w", &f3client.APIError{StatusCode: 404})
+
+	assert.True(t, f3client.IsNotFound(wrapped))
+	assert.False(t, f3client.IsConflict(wrapped))
+	assert.False(t, f3client.IsVersionMismatch(wrapped))
+	assert.False(t, f3client.IsRateLimited(wrapped))
+	assert.False(t, f3client.IsServerError(wrapped))
+	assert.False(t, f3client.IsNotFound(errors.New("some other error")))
+}
+
+func Test_Unit_APIError_Error(t *testing.T) {
+	withMessage := &f3client.APIError{StatusCode: 404, Message: "Account Not Found"}
+	withoutMessage := &f3client.APIError{StatusCode: 502, Method: "GET", URL: "http://localhost:8080/v1/organisation/accounts"}
+
+	assert.EqualError(t, withMessage, "Account Not Found")
+	assert.EqualError(t, withoutMessage, "GET http://localhost:8080/v1/organisation/accounts : 502 Bad Gateway")
+}
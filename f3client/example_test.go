@@ -2,6 +2,7 @@ package f3client_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 
@@ -36,8 +37,11 @@ func ExampleAccountService_Create() {
 		ID:             accountId,
 		OrganisationID: orgId,
 		Attributes: f3client.AccountAttributes{
-			Country: "GB",
-			Name:    []string{"jane doe", "john doe"},
+			Country:    "GB",
+			BankID:     "400300",
+			BankIDCode: f3client.BankIDCodeGB,
+			Bic:        "NWBKGB22",
+			Name:       []string{"jane doe", "john doe"},
 		},
 	}
 
@@ -106,3 +110,140 @@ func ExampleAccountService_Fetch() {
 	// do next operations with the new account object
 	fmt.Print(account)
 }
+
+func ExampleAccountService_Iterate() {
+	// create context
+	ctx := context.Background()
+
+	// create new f3client object
+	client, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	// iterate through all the accounts, 100 accounts are fetched per page
+	// the next page is fetched only once the current page is exhausted
+	it := client.Accounts.Iterate(&f3client.AccountListOptions{
+		ListOptions: f3client.ListOptions{PageSize: 100},
+	})
+	for it.Next(ctx) {
+		account := it.Account()
+		fmt.Println(account.ID)
+	}
+
+	// check for any error that stopped the iteration
+	if err := it.Err(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func ExampleAccountService_Update() {
+	// create context
+	ctx := context.Background()
+	accountId, err := uuid.Parse("bc8fb900-d6fd-41d0-b187-dc23ba928712")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// create new f3client object
+	client, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	// fetch the latest version of the account, change it and send it back
+	// if somebody else changed the account in the meantime, fetch it again and retry
+	for {
+		account, err := client.Accounts.Fetch(ctx, accountId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		account.Attributes.Name = []string{"jane smith"}
+
+		err = client.Accounts.Update(ctx, account)
+		var conflictErr *f3client.VersionConflictError
+		if errors.As(err, &conflictErr) {
+			continue
+		} else if err != nil {
+			log.Fatal(err)
+		}
+
+		break
+	}
+}
+
+func ExamplePaymentService_Create() {
+	// create new f3client, with default options
+	c, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	// ID, OrganisationID, Amount and Currency are mandatory while creating a payment
+	payment := f3client.Payment{
+		ID:             uuid.New(),
+		OrganisationID: uuid.New(),
+		Attributes: f3client.PaymentAttributes{
+			Amount:   "100.21",
+			Currency: "GBP",
+			BeneficiaryParty: &f3client.PaymentParty{
+				AccountNumber: "31926819",
+				AccountWith:   &f3client.AccountHoldingEntity{BankID: "403000", BankIDCode: "GBDSC"},
+				Name:          "Wilfred Jeremiah Owens",
+			},
+			ProcessingDate:    "2021-10-03",
+			SchemePaymentType: "ImmediatePayment",
+		},
+	}
+
+	err = c.Payments.Create(context.Background(), &payment)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func ExampleOrganisationService_Create() {
+	// create new f3client, with default options
+	c, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+	ctx := context.Background()
+
+	// the parent organisation is the one the credentials belong to
+	parentId, err := uuid.Parse("743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// provision the sub organisation under the parent
+	organisation := f3client.Organisation{
+		ID:             uuid.New(),
+		OrganisationID: parentId,
+		Attributes:     f3client.OrganisationAttributes{Name: "Acme Ltd"},
+	}
+
+	err = c.Organisations.Create(ctx, &organisation)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// and open its first account
+	account := f3client.Account{
+		ID:             uuid.New(),
+		OrganisationID: organisation.ID,
+		Attributes: f3client.AccountAttributes{
+			Country:    "GB",
+			BankID:     "400300",
+			BankIDCode: f3client.BankIDCodeGB,
+			Bic:        "NWBKGB22",
+			Name:       []string{"Acme Ltd"},
+		},
+	}
+
+	err = c.Accounts.Create(ctx, &account)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
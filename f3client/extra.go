@@ -0,0 +1,214 @@
+package f3client
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Extra holds the json fields of a resource that this version of the library does not know about.
+//
+// The fields are kept when a resource is decoded and sent back when it is encoded again, so that
+// fields added to the apis are not dropped by a read-modify-write cycle, example a Fetch followed
+// by an Update.
+type Extra map[string]json.RawMessage
+
+// The resources and the types nested in them implement json.Unmarshaler with unmarshalExtra and
+// json.Marshaler with marshalExtra to keep their extra fields, the methods are declared next to each
+// type. The type of a resource is ignored, it is always set from the service sending it.
+
+// unmarshalExtra decodes data into v, a pointer to a struct with an Extra field, and keeps the fields
+// of data that the struct has no field for in Extra. The fields named in ignore are dropped.
+//
+// The known fields are decoded one by one, so that the type of v is not decoded with its own
+// UnmarshalJSON again while the types of its fields still are.
+func unmarshalExtra(data []byte, v interface{}, ignore ...string) error {
+	var object map[string]json.RawMessage
+	err := json.Unmarshal(data, &object)
+	if err != nil || object == nil {
+		return err
+	}
+
+	value := reflect.ValueOf(v).Elem()
+	fields := jsonFields(value.Type())
+	extra := Extra{}
+
+	for name, raw := range object {
+		field, ok := findJSONField(fields, name)
+		if !ok {
+			if !containsName(ignore, name) {
+				extra[name] = raw
+			}
+			continue
+		}
+
+		err = json.Unmarshal(raw, value.FieldByIndex(field.index).Addr().Interface())
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(extra) == 0 {
+		extra = nil
+	}
+	value.FieldByName("Extra").Set(reflect.ValueOf(extra))
+
+	return nil
+}
+
+// marshalExtra encodes v, a struct with an Extra field, along with the extra fields. The known fields
+// come first in the order of the struct, a known field always takes precedence over an extra field
+// with the same name.
+func marshalExtra(v interface{}) ([]byte, error) {
+	value := reflect.ValueOf(v)
+	written := map[string]bool{}
+
+	buf := bytes.NewBufferString("{")
+	for _, field := range jsonFields(value.Type()) {
+		fieldValue := value.FieldByIndex(field.index)
+		if field.omitEmpty && isEmptyValue(fieldValue) {
+			continue
+		}
+
+		encoded, err := json.Marshal(fieldValue.Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		writeMember(buf, field.name, encoded)
+		written[field.name] = true
+	}
+
+	extra := value.FieldByName("Extra").Interface().(Extra)
+	names := make([]string, 0, len(extra))
+	for name := range extra {
+		if !written[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		writeMember(buf, name, extra[name])
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// writeMember writes the name and the encoded value as a member of the json object in buf
+func writeMember(buf *bytes.Buffer, name string, value []byte) {
+	if buf.Len() > 1 {
+		buf.WriteByte(',')
+	}
+
+	key, _ := json.Marshal(name)
+	buf.Write(key)
+	buf.WriteByte(':')
+	buf.Write(value)
+}
+
+// jsonField is a field of a struct that is encoded to json
+type jsonField struct {
+	index     []int
+	name      string
+	omitEmpty bool
+}
+
+// jsonFieldsCache holds the fields of the struct types seen so far, keyed by reflect.Type
+var jsonFieldsCache sync.Map
+
+// jsonFields returns the fields of the struct type t that are encoded to json, the fields of
+// embedded structs are returned as fields of t
+func jsonFields(t reflect.Type) []jsonField {
+	if fields, ok := jsonFieldsCache.Load(t); ok {
+		return fields.([]jsonField)
+	}
+
+	fields := collectJSONFields(t, nil)
+	jsonFieldsCache.Store(t, fields)
+	return fields
+}
+
+func collectJSONFields(t reflect.Type, index []int) []jsonField {
+	fields := []jsonField{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldIndex := append(append([]int{}, index...), i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		options := strings.Split(tag, ",")
+		name := options[0]
+		if name == "" && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, collectJSONFields(field.Type, fieldIndex)...)
+			continue
+		} else if field.PkgPath != "" {
+			// unexported fields are not encoded
+			continue
+		} else if name == "" {
+			name = field.Name
+		}
+
+		fields = append(fields, jsonField{
+			index:     fieldIndex,
+			name:      name,
+			omitEmpty: containsName(options[1:], "omitempty"),
+		})
+	}
+
+	return fields
+}
+
+// findJSONField returns the field with the json name, like encoding/json a field matching the
+// name exactly is preferred over a case-insensitive match
+func findJSONField(fields []jsonField, name string) (jsonField, bool) {
+	for _, field := range fields {
+		if field.name == name {
+			return field, true
+		}
+	}
+	for _, field := range fields {
+		if strings.EqualFold(field.name, name) {
+			return field, true
+		}
+	}
+
+	return jsonField{}, false
+}
+
+// isEmptyValue reports whether a field tagged with omitempty is left out, as done by encoding/json
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+
+	return false
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
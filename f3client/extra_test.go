@@ -0,0 +1,145 @@
+package f3client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unit_Extra_FetchUpdateRoundTrip(t *testing.T) {
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			json.NewDecoder(r.Body).Decode(&actualBody)
+		}
+		w.Write([]byte(`{"data": {"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "organisation_id": "ee2fb143-6dfe-4787-b183-de8ddd4164d1",
+			"type": "accounts", "version": 1, "meta": {"region": "eu-west-1"},
+			"attributes": {"country": "GB", "name": ["Jane Doe"], "nickname": "jd", "preferences": {"statements": "paper"}}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	account, err := client.Accounts.Fetch(context.Background(), uuid.MustParse("bc8fb900-d6fd-41d0-b187-dc23ba928712"))
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.JSONEq(t, `{"region": "eu-west-1"}`, string(account.Extra["meta"]))
+	assert.JSONEq(t, `"jd"`, string(account.Attributes.Extra["nickname"]))
+	assert.NotContains(t, account.Extra, "type")
+
+	account.Attributes.Name = []string{"Jane Smith"}
+	err = client.Accounts.Update(context.Background(), account)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	attributes := actualBody["data"]["attributes"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"region": "eu-west-1"}, actualBody["data"]["meta"])
+	assert.Equal(t, "jd", attributes["nickname"])
+	assert.Equal(t, map[string]interface{}{"statements": "paper"}, attributes["preferences"])
+	assert.Equal(t, []interface{}{"Jane Smith"}, attributes["name"])
+}
+
+func Test_Unit_Extra_KnownFieldTakesPrecedence(t *testing.T) {
+	attributes := f3client.AccountAttributes{
+		Country: "GB",
+		Extra:   f3client.Extra{"country": json.RawMessage(`"FR"`), "nickname": json.RawMessage(`"jd"`)},
+	}
+
+	encoded, err := json.Marshal(attributes)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.JSONEq(t, `{"country": "GB", "nickname": "jd"}`, string(encoded))
+}
+
+func Test_Unit_Extra_NoUnknownFields(t *testing.T) {
+	var payment f3client.Payment
+
+	err := json.Unmarshal([]byte(`{"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "type": "payments", "attributes": {"amount": "10.00"}}`), &payment)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Nil(t, payment.Extra)
+	assert.Nil(t, payment.Attributes.Extra)
+	assert.Equal(t, "10.00", payment.Attributes.Amount)
+}
+
+func Test_Unit_Extra_NestedTypes(t *testing.T) {
+	data := []byte(`{"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "organisation_id": "ee2fb143-6dfe-4787-b183-de8ddd4164d1", "type": "payments",
+		"attributes": {"amount": "10.00",
+		"beneficiary_party": {"account_number": "31926819", "nickname": "jd", "account_with": {"bank_id": "601613", "branch": "Soho"}},
+		"charges_information": {"bearer_code": "SHAR", "waived": true, "sender_charges": [{"amount": "1.00", "currency": "GBP", "tax": "0.20"}]}}}`)
+
+	var payment f3client.Payment
+	err := json.Unmarshal(data, &payment)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	beneficiary := payment.Attributes.BeneficiaryParty
+	assert.Equal(t, "31926819", beneficiary.AccountNumber)
+	assert.JSONEq(t, `"jd"`, string(beneficiary.Extra["nickname"]))
+	assert.JSONEq(t, `"Soho"`, string(beneficiary.AccountWith.Extra["branch"]))
+	assert.JSONEq(t, `true`, string(payment.Attributes.ChargesInformation.Extra["waived"]))
+	assert.JSONEq(t, `"0.20"`, string(payment.Attributes.ChargesInformation.SenderCharges[0].Extra["tax"]))
+	assert.Nil(t, payment.Extra)
+
+	encoded, err := json.Marshal(payment)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	var expected map[string]interface{}
+	json.Unmarshal(data, &expected)
+	delete(expected, "type")
+
+	assert.JSONEq(t, mustMarshal(expected), string(encoded))
+}
+
+func Test_Unit_Extra_NestedAccountAndMandateTypes(t *testing.T) {
+	var account f3client.Account
+	err := json.Unmarshal([]byte(`{"attributes": {"private_identification": {"identification": "13YH458762", "title": "Dr"},
+		"organisation_identification": {"actors": [{"name": ["Jeff Page"], "role": "director"}]}}}`), &account)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.JSONEq(t, `"Dr"`, string(account.Attributes.PrivateIdentification.Extra["title"]))
+	assert.JSONEq(t, `"director"`, string(account.Attributes.OrganisationIdentification.Actors[0].Extra["role"]))
+
+	var mandate f3client.Mandate
+	err = json.Unmarshal([]byte(`{"attributes": {"bacs": {"service_user_number": "112238", "reference_prefix": "F3"},
+		"sepa": {"creditor_identifier": "DE98ZZZ09999999999", "sequence": "FRST"}}}`), &mandate)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.JSONEq(t, `"F3"`, string(mandate.Attributes.Bacs.Extra["reference_prefix"]))
+	assert.JSONEq(t, `"FRST"`, string(mandate.Attributes.Sepa.Extra["sequence"]))
+}
+
+func mustMarshal(v interface{}) string {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return string(encoded)
+}
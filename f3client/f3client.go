@@ -4,11 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 // f3client struct constants
@@ -22,6 +23,7 @@ const (
 	Get    string = "GET"
 	Put    string = "PUT"
 	Post   string = "POST"
+	Patch  string = "PATCH"
 	Delete string = "DELETE"
 )
 
@@ -34,8 +36,26 @@ type Client struct {
 	UserAgent  string
 	Accepts    string
 
+	retryPolicy    *RetryPolicy
+	rateLimiter    *rateLimiter
+	requestTimeout time.Duration
+	signer         Signer
+	tokenSource    *tokenSource
+
+	idempotencyKeyFunc func() string
+	accountValidator   *AccountValidator
+
 	// Services for interacting with different parts of the API
-	Accounts *AccountService
+	Accounts      *AccountService
+	Payments      *PaymentService
+	Mandates      *MandateService
+	DirectDebits  *DirectDebitService
+	Subscriptions *SubscriptionService
+	Organisations *OrganisationService
+	Users         *UserService
+	Roles         *RoleService
+
+	ConfirmationOfPayee *ConfirmationOfPayeeService
 }
 
 type service struct {
@@ -65,6 +85,8 @@ func NewClient(options ...Option) (*Client, error) {
 		HttpClient: defaultHttpClient,
 		UserAgent:  UserAgent,
 		Accepts:    Accepts,
+
+		accountValidator: defaultAccountValidator,
 	}
 
 	for _, option := range options {
@@ -74,12 +96,49 @@ func NewClient(options ...Option) (*Client, error) {
 		}
 	}
 
+	// both authenticate the request through the Authorization header
+	if c.signer != nil && c.tokenSource != nil {
+		return c, NewArgError("options", "WithOAuth2 and WithSigner cannot be used together")
+	}
+
 	c.common.client = c
 
 	c.Accounts = &AccountService{
 		service:    c.common,
 		ObjectType: "accounts",
 	}
+	c.Payments = &PaymentService{
+		service:    c.common,
+		ObjectType: "payments",
+	}
+	c.Mandates = &MandateService{
+		service:    c.common,
+		ObjectType: "mandates",
+	}
+	c.DirectDebits = &DirectDebitService{
+		service:    c.common,
+		ObjectType: "directdebits",
+	}
+	c.Subscriptions = &SubscriptionService{
+		service:    c.common,
+		ObjectType: "subscriptions",
+	}
+	c.Organisations = &OrganisationService{
+		service:    c.common,
+		ObjectType: "organisations",
+	}
+	c.Users = &UserService{
+		service:    c.common,
+		ObjectType: "users",
+	}
+	c.Roles = &RoleService{
+		service:    c.common,
+		ObjectType: "roles",
+	}
+	c.ConfirmationOfPayee = &ConfirmationOfPayeeService{
+		service:    c.common,
+		ObjectType: "cop_requests",
+	}
 
 	return c, nil
 }
@@ -104,10 +163,25 @@ func WithHttpClient(customClient *http.Client) Option {
 	return f
 }
 
-// NewRequest creates http.Request object and returns a pointer to it
+// WithRequestTimeout configures f3client.Client to give up on calls that take longer than the timeout
+//
+// The timeout covers the whole call including retries and waiting for the rate limiter. It only applies
+// to calls whose context has no deadline, so a per call timeout can always be set with context.WithTimeout.
+func WithRequestTimeout(timeout time.Duration) Option {
+	f := func(c *Client) error {
+		if timeout < 0 {
+			return NewArgError("timeout", "timeout cannot be negative")
+		}
+		c.requestTimeout = timeout
+		return nil
+	}
+	return f
+}
+
+// NewRequest creates http.Request object bound to the context and returns a pointer to it
 //
 // if the request creation is not suceessful then it returns error and
-// the request object is returned as nil. It is a wrapper on http.NewRequest
+// the request object is returned as nil. It is a wrapper on http.NewRequestWithContext
 func (c *Client) NewRequest(ctx context.Context, method, urlStr, objectType string, body interface{}) (*http.Request, error) {
 	var u *url.URL
 	var err error
@@ -148,51 +222,69 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr, objectType stri
 		}
 	}
 
-	httpReq, err = http.NewRequest(method, u.String(), encodedBody)
+	if ctx == nil {
+		return nil, NewArgError("ctx", "ctx cannot be nil")
+	}
+
+	httpReq, err = http.NewRequestWithContext(ctx, method, u.String(), encodedBody)
 	if err != nil {
 		return nil, err
 	}
 
 	httpReq.ContentLength = contentLen
-	httpReq.Header.Add("Accepts", c.Accepts)
+	httpReq.Header.Add("Accept", c.Accepts)
 	httpReq.Header.Add("User-Agent", c.UserAgent)
+	if encodedBody != nil {
+		httpReq.Header.Add("Content-Type", c.Accepts)
+	}
+	if method == Post {
+		if key := c.idempotencyKey(ctx); key != "" {
+			httpReq.Header.Set(IdempotencyKeyHeader, key)
+		}
+	}
 
 	return httpReq, nil
 }
 
 // SendRequest executes the http request to the apis and returns their response
 //
-// An error is returned if there is any error in executing the request
+// An error is returned if there is any error in executing the request, when the api
+// responds with a 4xx or 5xx status code the error is an *APIError. The request is bound to ctx,
+// if ctx is cancelled or its deadline passes the returned error wraps ctx.Err()
 //
 // Arguments context object , pointer to http.Request object
 //
 // Returns f3cleint.Response struct, which contains the repose body
 func (c *Client) SendRequest(ctx context.Context, request *http.Request) (*Response, error) {
 	response := new(Response)
-	errorResponse := new(struct {
-		Code    string `json:"error_code,omitempty"`
-		Message string `json:"error_message,omitempty"`
-	})
 
-	httpResp, err := c.HttpClient.Do(request)
+	bodyBytes, attempts, err := c.do(ctx, request)
 	if err != nil {
 		return nil, err
+	} else if bodyBytes == nil {
+		return nil, nil
 	}
 
-	defer httpResp.Body.Close()
-
-	bodyBytes, err := ioutil.ReadAll(httpResp.Body)
+	err = json.Unmarshal(bodyBytes, response)
 	if err != nil {
 		return nil, err
 	}
+	response.Attempts = attempts
 
-	if httpResp.StatusCode >= 400 && httpResp.StatusCode <= 504 {
-		err = json.Unmarshal(bodyBytes, errorResponse)
-		if err != nil {
-			return nil, err
-		}
-		return nil, fmt.Errorf(errorResponse.Message)
-	} else if httpResp.StatusCode == 204 {
+	return response, nil
+}
+
+// SendListRequest executes the http request to a form3 list api and returns their response
+//
+// It behaves exactly like SendRequest, except that the data field of the response
+// is expected to be an array of resources instead of a single resource
+func (c *Client) SendListRequest(ctx context.Context, request *http.Request) (*ListResponse, error) {
+	response := new(ListResponse)
+
+	bodyBytes, attempts, err := c.do(ctx, request)
+	if err != nil {
+		return nil, err
+	} else if bodyBytes == nil {
 		return nil, nil
 	}
 
@@ -200,6 +292,146 @@ func (c *Client) SendRequest(ctx context.Context, request *http.Request) (*Respo
 	if err != nil {
 		return nil, err
 	}
+	response.Attempts = attempts
 
 	return response, nil
 }
+
+// do executes the http request, throttling and retrying it as per the rate limit and
+// retry policy of the client, and reads the response body. It also returns the number of attempts made.
+//
+// For 4xx and 5xx status codes an *APIError is returned.
+// A nil body and nil error is returned when the api responds with 204 No Content
+func (c *Client) do(ctx context.Context, request *http.Request) ([]byte, int, error) {
+	if ctx == nil {
+		ctx = request.Context()
+	}
+
+	if _, ok := ctx.Deadline(); !ok && c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+	request = request.WithContext(ctx)
+
+	bodyBytes, attempts, err := c.doAttempts(ctx, request)
+
+	// make cancellations and timeouts recognisable with errors.Is
+	if err != nil && ctx.Err() != nil && !errors.Is(err, ctx.Err()) {
+		err = &contextError{err: err, ctxErr: ctx.Err()}
+	}
+
+	return bodyBytes, attempts, err
+}
+
+// doAttempts sends the request until it succeeds or there are no more attempts left
+func (c *Client) doAttempts(ctx context.Context, request *http.Request) ([]byte, int, error) {
+	maxAttempts := c.retryPolicy.maxAttempts(request)
+	reauthenticated := false
+
+	attemptReq := request
+	for attempt := 1; ; attempt++ {
+		var err error
+		if attempt > 1 {
+			attemptReq, err = rewind(ctx, request)
+			if err != nil {
+				return nil, attempt, err
+			}
+		}
+
+		if c.rateLimiter != nil {
+			err = c.rateLimiter.wait(ctx)
+			if err != nil {
+				return nil, attempt, err
+			}
+		}
+
+		var token string
+		if c.tokenSource != nil {
+			token, err = c.tokenSource.Token(ctx)
+			if err != nil {
+				return nil, attempt, err
+			}
+			attemptReq.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		if c.signer != nil {
+			err = c.sign(attemptReq)
+			if err != nil {
+				return nil, attempt, err
+			}
+		}
+
+		httpResp, bodyBytes, err := c.roundTrip(attemptReq)
+		if c.rateLimiter != nil {
+			c.rateLimiter.update(httpResp)
+		}
+
+		// the token might have been revoked before its expiry, get a fresh
+		// one and try once more without counting it as a retry
+		if err == nil && httpResp.StatusCode == http.StatusUnauthorized && c.tokenSource != nil &&
+			!reauthenticated && canRewind(request) {
+			reauthenticated = true
+			c.tokenSource.invalidate(token)
+			maxAttempts++
+			continue
+		}
+		if err == nil && httpResp.StatusCode < 400 {
+			if httpResp.StatusCode == 204 {
+				return nil, attempt, nil
+			}
+			return bodyBytes, attempt, nil
+		}
+
+		if attempt >= maxAttempts || !c.retryPolicy.shouldRetry(ctx, httpResp, err) {
+			if err != nil {
+				if attempt > 1 {
+					return nil, attempt, &RetryError{Attempts: attempt, Err: err}
+				}
+				return nil, attempt, err
+			}
+
+			apiErr := newAPIError(httpResp, bodyBytes)
+			apiErr.Attempts = attempt
+			return nil, attempt, apiErr
+		}
+
+		// the context may end while waiting for the next attempt, in which case the
+		// failure of the last attempt is returned so that callers can still inspect it
+		if sleepErr := sleep(ctx, c.retryPolicy.delay(attempt, httpResp)); sleepErr != nil {
+			if err == nil {
+				apiErr := newAPIError(httpResp, bodyBytes)
+				apiErr.Attempts = attempt
+				err = apiErr
+			}
+			return nil, attempt, &RetryError{Attempts: attempt, Err: err}
+		}
+	}
+}
+
+// sign passes the request and its body to the signer of the client
+func (c *Client) sign(request *http.Request) error {
+	body, err := requestBody(request)
+	if err != nil {
+		return err
+	}
+
+	return c.signer.Sign(request, body)
+}
+
+// roundTrip makes a single attempt of the http request and reads the whole response body
+func (c *Client) roundTrip(request *http.Request) (*http.Response, []byte, error) {
+	httpResp, err := c.HttpClient.Do(request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer httpResp.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return httpResp, bodyBytes, nil
+}
@@ -2,11 +2,15 @@ package f3client_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -125,3 +129,188 @@ func Test_Unit_SendRequest_EmptyRequest(t *testing.T) {
 	}
 
 }
+
+func Test_Unit_SendRequest_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "abc")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error_code": "Not Found", "error_message": "Account Not Found"}`))
+	}))
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	req, err := c.NewRequest(context.Background(), f3client.Get, "/v1/organisation/accounts/123", c.Accounts.ObjectType, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = c.SendRequest(context.Background(), req)
+
+	var apiErr *f3client.APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.Equal(t, "Not Found", apiErr.Code)
+		assert.Equal(t, "Account Not Found", apiErr.Message)
+		assert.Equal(t, f3client.Get, apiErr.Method)
+		assert.Equal(t, server.URL+"/v1/organisation/accounts/123", apiErr.URL)
+		assert.Equal(t, "abc", apiErr.Header.Get("X-Request-Id"))
+		assert.True(t, f3client.IsNotFound(err))
+	}
+}
+
+func Test_Unit_SendRequest_NonJsonErrorBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`<html><body>502 Bad Gateway</body></html>`))
+	}))
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	req, err := c.NewRequest(context.Background(), f3client.Get, "/v1/organisation/accounts", c.Accounts.ObjectType, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = c.SendRequest(context.Background(), req)
+
+	var apiErr *f3client.APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+		assert.Empty(t, apiErr.Message)
+		assert.Equal(t, []byte(`<html><body>502 Bad Gateway</body></html>`), apiErr.Body)
+		assert.True(t, f3client.IsServerError(err))
+	}
+}
+
+func Test_Unit_SendRequest_UnknownServerErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(599)
+	}))
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	req, err := c.NewRequest(context.Background(), f3client.Get, "/v1/organisation/accounts", c.Accounts.ObjectType, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = c.SendRequest(context.Background(), req)
+
+	assert.True(t, f3client.IsServerError(err))
+}
+
+// ------------- f3client context propagation unit tests -------------//
+
+// newSlowServer returns a server that only responds once the client goes away
+// or the test is over
+func newSlowServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+}
+
+func Test_Unit_NewRequest_BindsContext(t *testing.T) {
+	c, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+	req, err := c.NewRequest(ctx, f3client.Get, "/v1/organisation/accounts", c.Accounts.ObjectType, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	assert.Equal(t, "value", req.Context().Value(ctxKey{}))
+}
+
+func Test_Unit_Fetch_CancelledContext(t *testing.T) {
+	server := newSlowServer()
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err = c.Accounts.Fetch(ctx, uuid.New())
+
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+}
+
+func Test_Unit_Fetch_ContextDeadline(t *testing.T) {
+	server := newSlowServer()
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = c.Accounts.Fetch(ctx, uuid.New())
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func Test_Unit_Fetch_WithRequestTimeout(t *testing.T) {
+	server := newSlowServer()
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithRequestTimeout(50*time.Millisecond))
+	if err != nil {
+		panic(err)
+	}
+
+	start := time.Now()
+	_, err = c.Accounts.Fetch(context.Background(), uuid.New())
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+}
+
+func Test_Unit_SendRequest_ContextOverridesRequestContext(t *testing.T) {
+	server := newSlowServer()
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	req, err := c.NewRequest(context.Background(), f3client.Get, "/v1/organisation/accounts", c.Accounts.ObjectType, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = c.SendRequest(ctx, req)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
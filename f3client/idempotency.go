@@ -0,0 +1,120 @@
+package f3client
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/google/uuid"
+)
+
+// idempotencyKeyCtx is the context key under which the idempotency key is stored
+type idempotencyKeyCtx struct{}
+
+// ContextWithIdempotencyKey returns a copy of ctx carrying the idempotency key. POST requests created
+// with the returned context are sent with the key, so that the api can recognise a repeated request.
+//
+// Example
+//
+//	ctx = f3client.ContextWithIdempotencyKey(ctx, paymentInstructionId)
+//	err = client.Accounts.Create(ctx, &account)
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
+
+// IdempotencyKeyFromContext returns the idempotency key carried by ctx, if any
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyCtx{}).(string)
+	return key, ok && key != ""
+}
+
+// WithIdempotencyKeyFunc configures f3client.Client to send every POST request with an idempotency key
+// generated by the func being passed, unless the request context already carries a key
+//
+// When retries are enabled with WithRetry and no func is configured, a random uuid is used as key.
+func WithIdempotencyKeyFunc(keyFunc func() string) Option {
+	f := func(c *Client) error {
+		if keyFunc == nil {
+			return NewArgError("keyFunc", "keyFunc cannot be nil")
+		}
+		c.idempotencyKeyFunc = keyFunc
+		return nil
+	}
+	return f
+}
+
+// idempotencyKey returns the key a POST request is sent with, an empty
+// string means that the request is sent without key
+func (c *Client) idempotencyKey(ctx context.Context) string {
+	if key, ok := IdempotencyKeyFromContext(ctx); ok {
+		return key
+	}
+
+	if c.idempotencyKeyFunc != nil {
+		return c.idempotencyKeyFunc()
+	}
+
+	// retried creates must be recognisable by the api
+	if c.retryPolicy != nil {
+		return uuid.New().String()
+	}
+
+	return ""
+}
+
+// isDuplicate reports whether existing is the resource that the sent resource would have created,
+// i.e. every field of sent, except the ones managed by the api, has the same value in existing
+func isDuplicate(sent, existing interface{}) bool {
+	sentMap, err := toMap(sent)
+	if err != nil {
+		return false
+	}
+	existingMap, err := toMap(existing)
+	if err != nil {
+		return false
+	}
+
+	for _, field := range []string{"version", "created_on", "modified_on"} {
+		delete(sentMap, field)
+	}
+
+	return isSubset(sentMap, existingMap)
+}
+
+// isSubset reports whether every key of sub has the same value in super, nested objects are compared
+// the same way so that defaults filled in by the api do not make the objects differ
+func isSubset(sub, super map[string]interface{}) bool {
+	for key, value := range sub {
+		superValue, ok := super[key]
+		if !ok {
+			return false
+		}
+
+		subObject, subIsObject := value.(map[string]interface{})
+		superObject, superIsObject := superValue.(map[string]interface{})
+		if subIsObject && superIsObject {
+			if !isSubset(subObject, superObject) {
+				return false
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(value, superValue) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// toMap converts a resource to its generic json representation
+func toMap(resource interface{}) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]interface{}{}
+	err = json.Unmarshal(encoded, &m)
+	return m, err
+}
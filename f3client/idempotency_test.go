@@ -0,0 +1,158 @@
+package f3client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestAccount returns an account that passes the create validations
+func newTestAccount() *f3client.Account {
+	accountUUID, _ := uuid.Parse("bc8fb900-d6fd-41d0-b187-dc23ba928712")
+	organisationUUID, _ := uuid.Parse("ee2fb143-6dfe-4787-b183-de8ddd4164d1")
+
+	return &f3client.Account{
+		ID:             accountUUID,
+		OrganisationID: organisationUUID,
+		Attributes: f3client.AccountAttributes{
+			Country:    "GB",
+			BankID:     "400300",
+			BankIDCode: f3client.BankIDCodeGB,
+			Bic:        "NWBKGB22",
+			Name:       []string{"Jon Doe"},
+		},
+	}
+}
+
+// newIdempotencyKeyServer returns a server that reports the idempotency key of every request
+func newIdempotencyKeyServer(keys chan<- string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys <- r.Header.Get(f3client.IdempotencyKeyHeader)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "organisation_id": "ee2fb143-6dfe-4787-b183-de8ddd4164d1", "type": "accounts", "attributes": {"country": "GB", "bank_id": "400300", "bank_id_code": "GBDSC", "bic": "NWBKGB22", "name": ["Jon Doe"]}}}`))
+	}))
+}
+
+func Test_Unit_IdempotencyKey_FromContext(t *testing.T) {
+	keys := make(chan string, 1)
+	server := newIdempotencyKeyServer(keys)
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	ctx := f3client.ContextWithIdempotencyKey(context.Background(), "instruction-42")
+	err = c.Accounts.Create(ctx, newTestAccount())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "instruction-42", <-keys)
+}
+
+func Test_Unit_IdempotencyKey_KeyFunc(t *testing.T) {
+	keys := make(chan string, 1)
+	server := newIdempotencyKeyServer(keys)
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithIdempotencyKeyFunc(func() string { return "generated-key" }))
+	if err != nil {
+		panic(err)
+	}
+
+	err = c.Accounts.Create(context.Background(), newTestAccount())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "generated-key", <-keys)
+}
+
+func Test_Unit_IdempotencyKey_GeneratedWhenRetrying(t *testing.T) {
+	keys := make(chan string, 1)
+	server := newIdempotencyKeyServer(keys)
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithRetry(f3client.DefaultRetryPolicy()))
+	if err != nil {
+		panic(err)
+	}
+
+	err = c.Accounts.Create(context.Background(), newTestAccount())
+	assert.NoError(t, err)
+
+	_, err = uuid.Parse(<-keys)
+	assert.NoError(t, err)
+}
+
+func Test_Unit_IdempotencyKey_NotSentByDefault(t *testing.T) {
+	keys := make(chan string, 1)
+	server := newIdempotencyKeyServer(keys)
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	err = c.Accounts.Create(context.Background(), newTestAccount())
+
+	assert.NoError(t, err)
+	assert.Empty(t, <-keys)
+}
+
+func Test_Unit_AccountService_Create_DuplicateResolvedToFetch(t *testing.T) {
+	// the account was created by an earlier attempt, create reports a conflict
+	// and fetch returns the existing account
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error_message": "Account cannot be created as it violates a duplicate constraint"}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "organisation_id": "ee2fb143-6dfe-4787-b183-de8ddd4164d1",
+			"type": "accounts", "version": 0, "created_on": "2021-10-03T13:44:27.809Z",
+			"attributes": {"country": "GB", "bank_id": "400300", "bank_id_code": "GBDSC", "bic": "NWBKGB22", "name": ["Jon Doe"], "status": "confirmed"}}}`))
+	}))
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	account := newTestAccount()
+	err = c.Accounts.Create(context.Background(), account)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-10-03T13:44:27.809Z", account.CreatedOn)
+	assert.Equal(t, "confirmed", account.Attributes.Status)
+}
+
+func Test_Unit_AccountService_Create_ConflictWithDifferentAccount(t *testing.T) {
+	// an account with the same id but different attributes exists
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error_message": "Account cannot be created as it violates a duplicate constraint"}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "organisation_id": "ee2fb143-6dfe-4787-b183-de8ddd4164d1",
+			"type": "accounts", "attributes": {"country": "FR", "name": ["Somebody Else"]}}}`))
+	}))
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	account := newTestAccount()
+	err = c.Accounts.Create(context.Background(), account)
+
+	assert.True(t, f3client.IsConflict(err))
+	assert.Equal(t, newTestAccount(), account)
+}
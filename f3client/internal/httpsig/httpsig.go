@@ -0,0 +1,41 @@
+// Package httpsig holds the parts of the form3 http signature scheme shared by the
+// client, which signs the requests it sends, and the webhook package, which verifies
+// the requests it receives.
+package httpsig
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// SigningString builds the string covered by the signature, one line per header
+//
+// The host is taken from the request, falling back to its url for outgoing requests,
+// and the content length from its header, falling back to the length of the request body.
+func SigningString(request *http.Request, headers []string) string {
+	lines := make([]string, 0, len(headers))
+
+	for _, header := range headers {
+		var value string
+		switch header {
+		case "(request-target)":
+			value = strings.ToLower(request.Method) + " " + request.URL.RequestURI()
+		case "host":
+			value = request.Host
+			if value == "" {
+				value = request.URL.Host
+			}
+		case "content-length":
+			value = request.Header.Get(header)
+			if value == "" {
+				value = strconv.FormatInt(request.ContentLength, 10)
+			}
+		default:
+			value = request.Header.Get(header)
+		}
+		lines = append(lines, header+": "+value)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,39 @@
+package httpsig_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/benjaminmishra/form3-client-go/v1/f3client/internal/httpsig"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unit_SigningString(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://api.form3.tech/v1/organisation/accounts?page[size]=1", strings.NewReader("{}"))
+	if err != nil {
+		panic(err)
+	}
+	req.Header.Set("Date", "Sun, 03 Oct 2021 13:44:27 GMT")
+
+	expected := "(request-target): post /v1/organisation/accounts?page[size]=1\n" +
+		"host: api.form3.tech\n" +
+		"date: Sun, 03 Oct 2021 13:44:27 GMT\n" +
+		"content-length: 2"
+
+	assert.Equal(t, expected, httpsig.SigningString(req, []string{"(request-target)", "host", "date", "content-length"}))
+}
+
+func Test_Unit_SigningString_HeadersTakePrecedence(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://api.form3.tech/v1/organisation/accounts", strings.NewReader("{}"))
+	if err != nil {
+		panic(err)
+	}
+	req.Host = "webhooks.example.com"
+	req.Header.Set("Content-Length", "3")
+
+	expected := "host: webhooks.example.com\n" +
+		"content-length: 3"
+
+	assert.Equal(t, expected, httpsig.SigningString(req, []string{"host", "content-length"}))
+}
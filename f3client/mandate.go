@@ -0,0 +1,256 @@
+package f3client
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type MandateService struct {
+	service
+	ObjectType string
+}
+
+// Direct debit payment schemes
+const (
+	PaymentSchemeBacs   string = "BACS"
+	PaymentSchemeSepaDD string = "SEPADD"
+)
+
+// MandateStatusCancelled is the status of a mandate that has been cancelled
+const MandateStatusCancelled string = "cancelled"
+
+// SequenceType is the position of a SEPA direct debit in the series of collections of a mandate
+type SequenceType string
+
+// SEPA direct debit sequence types
+const (
+	SequenceTypeFirst     SequenceType = "FRST"
+	SequenceTypeRecurring SequenceType = "RCUR"
+	SequenceTypeOneOff    SequenceType = "OOFF"
+	SequenceTypeFinal     SequenceType = "FNAL"
+)
+
+// IsValid reports whether the sequence type is one of the SEPA sequence types
+func (st SequenceType) IsValid() bool {
+	switch st {
+	case SequenceTypeFirst, SequenceTypeRecurring, SequenceTypeOneOff, SequenceTypeFinal:
+		return true
+	}
+	return false
+}
+
+// BacsSchemeData holds the Bacs specific fields of a mandate or a direct debit
+type BacsSchemeData struct {
+	// ServiceUserNumber identifies the originator of the collections within Bacs
+	ServiceUserNumber string `json:"service_user_number,omitempty"`
+	TransactionCode   string `json:"transaction_code,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (bd *BacsSchemeData) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, bd)
+}
+
+func (bd BacsSchemeData) MarshalJSON() ([]byte, error) {
+	return marshalExtra(bd)
+}
+
+// SepaSchemeData holds the SEPA specific fields of a mandate or a direct debit
+type SepaSchemeData struct {
+	// CreditorID identifies the creditor within SEPA
+	CreditorID    string       `json:"creditor_id,omitempty"`
+	SequenceType  SequenceType `json:"sequence_type,omitempty"`
+	SignatureDate string       `json:"signature_date,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (sd *SepaSchemeData) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, sd)
+}
+
+func (sd SepaSchemeData) MarshalJSON() ([]byte, error) {
+	return marshalExtra(sd)
+}
+
+// Mandate represents a direct debit mandate, i.e. the authorisation given by the debtor
+// to the beneficiary to collect payments from their account.
+//
+// See the Mandates section of https://api-docs.form3.tech/api.html for
+// more information about fields.
+type Mandate struct {
+	ID             uuid.UUID         `json:"id,omitempty"`
+	Version        int               `json:"version,omitempty"`
+	OrganisationID uuid.UUID         `json:"organisation_id,omitempty"`
+	CreatedOn      string            `json:"created_on,omitempty"`
+	ModifiedOn     string            `json:"modified_on,omitempty"`
+	Attributes     MandateAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (m *Mandate) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, m, "type")
+}
+
+func (m Mandate) MarshalJSON() ([]byte, error) {
+	return marshalExtra(m)
+}
+
+type MandateAttributes struct {
+	BeneficiaryParty     *PaymentParty   `json:"beneficiary_party,omitempty"`
+	DebtorParty          *PaymentParty   `json:"debtor_party,omitempty"`
+	PaymentScheme        string          `json:"payment_scheme,omitempty"`
+	Reference            string          `json:"reference,omitempty"`
+	SchemeProcessingDate string          `json:"scheme_processing_date,omitempty"`
+	Status               string          `json:"status,omitempty"`
+	StatusReason         string          `json:"status_reason,omitempty"`
+	Bacs                 *BacsSchemeData `json:"bacs,omitempty"`
+	Sepa                 *SepaSchemeData `json:"sepa,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ma *MandateAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ma)
+}
+
+func (ma MandateAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ma)
+}
+
+// MandateSubmission represents the submission of a mandate to the payment scheme
+type MandateSubmission struct {
+	ID             uuid.UUID                   `json:"id,omitempty"`
+	Version        int                         `json:"version,omitempty"`
+	OrganisationID uuid.UUID                   `json:"organisation_id,omitempty"`
+	CreatedOn      string                      `json:"created_on,omitempty"`
+	ModifiedOn     string                      `json:"modified_on,omitempty"`
+	Attributes     MandateSubmissionAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ms *MandateSubmission) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ms, "type")
+}
+
+func (ms MandateSubmission) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ms)
+}
+
+type MandateSubmissionAttributes struct {
+	Status             SubmissionStatus `json:"status,omitempty"`
+	StatusReason       string           `json:"status_reason,omitempty"`
+	SchemeStatusCode   string           `json:"scheme_status_code,omitempty"`
+	SubmissionDatetime string           `json:"submission_datetime,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (msa *MandateSubmissionAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, msa)
+}
+
+func (msa MandateSubmissionAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(msa)
+}
+
+// validateSchemeData checks that the scheme specific fields required by the payment scheme are present
+func validateSchemeData(paymentScheme string, bacs *BacsSchemeData, sepa *SepaSchemeData) error {
+	switch paymentScheme {
+	case PaymentSchemeBacs:
+		if bacs == nil || bacs.ServiceUserNumber == "" {
+			return NewArgError("bacs", "service_user_number is mandatory for Bacs")
+		}
+	case PaymentSchemeSepaDD:
+		if sepa == nil || sepa.CreditorID == "" {
+			return NewArgError("sepa", "creditor_id is mandatory for SEPA direct debit")
+		}
+		if sepa.SequenceType != "" && !sepa.SequenceType.IsValid() {
+			return NewArgError("sequence_type", "sequence_type must be one of FRST, RCUR, OOFF or FNAL")
+		}
+	case "":
+		return NewArgError("payment_scheme", "payment_scheme is mandatory")
+	}
+	return nil
+}
+
+// Create creates a mandate using form3 mandate api, the mandate is updated in place
+// with the mandate sent back by the api
+//
+// Bacs mandates require the service user number and SEPA mandates the creditor id.
+func (ms *MandateService) Create(ctx context.Context, mandate *Mandate) error {
+	// validate for mandatory mandate fields before creating new request
+	err := validateSchemeData(mandate.Attributes.PaymentScheme, mandate.Attributes.Bacs, mandate.Attributes.Sepa)
+	if err != nil {
+		return err
+	}
+
+	return ms.create(ctx, "/v1/transaction/mandates", ms.ObjectType, mandate.ID, mandate)
+}
+
+// Fetch gets the mandate with the id, an unknown id results in an *APIError matching ErrNotFound
+func (ms *MandateService) Fetch(ctx context.Context, mandateId uuid.UUID) (*Mandate, error) {
+	mandate := new(Mandate)
+
+	err := ms.fetch(ctx, "/v1/transaction/mandates/"+mandateId.String(), ms.ObjectType, mandate)
+	if err != nil {
+		return nil, err
+	}
+
+	return mandate, nil
+}
+
+// List gets a single page of form3 mandate objects, when opts is nil the first
+// page with the api default page size is returned
+func (ms *MandateService) List(ctx context.Context, opts *ListOptions) ([]Mandate, Links, error) {
+	mandates := []Mandate{}
+
+	links, err := ms.list(ctx, addQuery("/v1/transaction/mandates", opts.values()), ms.ObjectType, &mandates)
+	if err != nil {
+		return nil, Links{}, err
+	}
+
+	return mandates, links, nil
+}
+
+// Cancel cancels the mandate, no further direct debits can be collected under a cancelled mandate.
+// The mandate is updated in place with the mandate sent back by the api.
+//
+// The version of the mandate has to be the current one, otherwise *VersionConflictError is returned.
+func (ms *MandateService) Cancel(ctx context.Context, mandate *Mandate) error {
+	cancelled := *mandate
+	cancelled.Attributes.Status = MandateStatusCancelled
+
+	err := ms.update(ctx, "/v1/transaction/mandates/"+mandate.ID.String(), ms.ObjectType, mandate.ID, mandate.Version, &cancelled)
+	if err != nil {
+		return err
+	}
+
+	*mandate = cancelled
+	return nil
+}
+
+// CreateSubmission submits a mandate to the payment scheme, the submission is updated in place
+// with the submission sent back by the api
+func (ms *MandateService) CreateSubmission(ctx context.Context, mandateId uuid.UUID, submission *MandateSubmission) error {
+	path := "/v1/transaction/mandates/" + mandateId.String() + "/submissions"
+
+	return ms.create(ctx, path, "mandate_submissions", submission.ID, submission)
+}
+
+// FetchSubmission gets the submission of a mandate
+func (ms *MandateService) FetchSubmission(ctx context.Context, mandateId, submissionId uuid.UUID) (*MandateSubmission, error) {
+	submission := new(MandateSubmission)
+	path := "/v1/transaction/mandates/" + mandateId.String() + "/submissions/" + submissionId.String()
+
+	err := ms.fetch(ctx, path, "mandate_submissions", submission)
+	if err != nil {
+		return nil, err
+	}
+
+	return submission, nil
+}
@@ -0,0 +1,211 @@
+package f3client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const mandateResponse = `
+	{
+		"data": {
+			"attributes": {
+				"payment_scheme": "SEPADD",
+				"reference": "MANDATE-001",
+				"status": "pending",
+				"sepa": {"creditor_id": "DE98ZZZ09999999999", "sequence_type": "FRST", "signature_date": "2021-10-01"}
+			},
+			"id": "a3d5f0b1-6c2e-4f7a-9b8d-1e2f3a4b5c6d",
+			"organisation_id": "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb",
+			"type": "mandates",
+			"version": 0
+		}
+	}`
+
+func Test_Unit_SequenceType_IsValid(t *testing.T) {
+	assert.True(t, f3client.SequenceTypeFirst.IsValid())
+	assert.True(t, f3client.SequenceType("FNAL").IsValid())
+	assert.False(t, f3client.SequenceType("LAST").IsValid())
+}
+
+func Test_Unit_MandateService_Create(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(mandateResponse))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	mandateUUID, _ := uuid.Parse("a3d5f0b1-6c2e-4f7a-9b8d-1e2f3a4b5c6d")
+	organisationUUID, _ := uuid.Parse("743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb")
+
+	mandate := &f3client.Mandate{
+		ID:             mandateUUID,
+		OrganisationID: organisationUUID,
+		Attributes: f3client.MandateAttributes{
+			PaymentScheme: f3client.PaymentSchemeSepaDD,
+			Reference:     "MANDATE-001",
+			Sepa: &f3client.SepaSchemeData{
+				CreditorID:    "DE98ZZZ09999999999",
+				SequenceType:  f3client.SequenceTypeFirst,
+				SignatureDate: "2021-10-01",
+			},
+		},
+	}
+
+	err = client.Mandates.Create(context.Background(), mandate)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/mandates", actualPath)
+	assert.Equal(t, "mandates", actualBody["data"]["type"])
+	assert.Equal(t, "pending", mandate.Attributes.Status)
+	assert.Equal(t, f3client.SequenceTypeFirst, mandate.Attributes.Sepa.SequenceType)
+}
+
+func Test_Unit_MandateService_Create_MissingSchemeData(t *testing.T) {
+	client, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	cases := map[string]f3client.MandateAttributes{
+		"no payment scheme":      {},
+		"bacs without sun":       {PaymentScheme: f3client.PaymentSchemeBacs, Bacs: &f3client.BacsSchemeData{}},
+		"sepa without creditor":  {PaymentScheme: f3client.PaymentSchemeSepaDD},
+		"sepa bad sequence type": {PaymentScheme: f3client.PaymentSchemeSepaDD, Sepa: &f3client.SepaSchemeData{CreditorID: "DE98ZZZ09999999999", SequenceType: "LAST"}},
+	}
+
+	for name, attributes := range cases {
+		mandate := &f3client.Mandate{ID: uuid.New(), OrganisationID: uuid.New(), Attributes: attributes}
+
+		err = client.Mandates.Create(context.Background(), mandate)
+
+		var targetErr *f3client.ArgumentError
+		assert.ErrorAs(t, err, &targetErr, name)
+	}
+}
+
+func Test_Unit_MandateService_Cancel(t *testing.T) {
+	var actualMethod, actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualMethod = r.Method
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.Write([]byte(`{"data": {"id": "a3d5f0b1-6c2e-4f7a-9b8d-1e2f3a4b5c6d", "type": "mandates", "version": 1,
+			"attributes": {"payment_scheme": "BACS", "status": "cancelled", "bacs": {"service_user_number": "112238"}}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	mandateUUID, _ := uuid.Parse("a3d5f0b1-6c2e-4f7a-9b8d-1e2f3a4b5c6d")
+
+	mandate := &f3client.Mandate{
+		ID:             mandateUUID,
+		OrganisationID: uuid.New(),
+		Attributes: f3client.MandateAttributes{
+			PaymentScheme: f3client.PaymentSchemeBacs,
+			Bacs:          &f3client.BacsSchemeData{ServiceUserNumber: "112238"},
+		},
+	}
+
+	err = client.Mandates.Cancel(context.Background(), mandate)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, http.MethodPatch, actualMethod)
+	assert.Equal(t, "/v1/transaction/mandates/a3d5f0b1-6c2e-4f7a-9b8d-1e2f3a4b5c6d", actualPath)
+	assert.Equal(t, "cancelled", actualBody["data"]["attributes"].(map[string]interface{})["status"])
+	assert.Equal(t, f3client.MandateStatusCancelled, mandate.Attributes.Status)
+	assert.Equal(t, 1, mandate.Version)
+}
+
+func Test_Unit_MandateService_Cancel_VersionConflict(t *testing.T) {
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error_message": "invalid version"}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	mandate := &f3client.Mandate{ID: uuid.New(), OrganisationID: uuid.New(), Version: 2}
+
+	err = client.Mandates.Cancel(context.Background(), mandate)
+
+	var conflictErr *f3client.VersionConflictError
+	if assert.True(t, errors.As(err, &conflictErr)) {
+		assert.Equal(t, 2, conflictErr.Version)
+	}
+	assert.Empty(t, mandate.Attributes.Status)
+}
+
+func Test_Unit_MandateService_CreateSubmission(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "7f1a3c1e-5b8e-4f55-9c0b-2d3a8e6f4b21", "type": "mandate_submissions", "attributes": {"status": "accepted"}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	mandateUUID, _ := uuid.Parse("a3d5f0b1-6c2e-4f7a-9b8d-1e2f3a4b5c6d")
+
+	submission := &f3client.MandateSubmission{ID: uuid.New(), OrganisationID: uuid.New()}
+
+	err = client.Mandates.CreateSubmission(context.Background(), mandateUUID, submission)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/mandates/a3d5f0b1-6c2e-4f7a-9b8d-1e2f3a4b5c6d/submissions", actualPath)
+	assert.Equal(t, "mandate_submissions", actualBody["data"]["type"])
+	assert.Equal(t, f3client.SubmissionStatusAccepted, submission.Attributes.Status)
+}
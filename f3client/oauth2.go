@@ -0,0 +1,205 @@
+package f3client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OAuth2Config holds the client credentials used to obtain bearer tokens, see WithOAuth2
+type OAuth2Config struct {
+	ClientID     string
+	ClientSecret string
+
+	// TokenURL is the token endpoint, it defaults to /v1/oauth2/token on the base url of the client
+	TokenURL string
+
+	// ExpiryDelta is how long before its expiry a token is refreshed, it defaults to 30 seconds.
+	// It is capped at half the lifetime of the token, so that short lived tokens are still reused.
+	ExpiryDelta time.Duration
+}
+
+// WithOAuth2 configures f3client.Client to authenticate every request with a bearer token
+// obtained from the token endpoint using the oauth2 client credentials grant
+//
+// Tokens are cached and shared by all the services and goroutines using the client, only one
+// of them fetches a new token when the cached one is about to expire. When the api rejects a
+// token with 401 the request is sent once more with a fresh token. Tokens are fetched using the
+// http.Client of the client, so this can be combined with WithHttpClient, but not with WithSigner.
+func WithOAuth2(config OAuth2Config) Option {
+	f := func(c *Client) error {
+		if config.ClientID == "" {
+			return NewArgError("ClientID", "ClientID cannot be empty")
+		}
+		if config.ClientSecret == "" {
+			return NewArgError("ClientSecret", "ClientSecret cannot be empty")
+		}
+		if config.ExpiryDelta == 0 {
+			config.ExpiryDelta = 30 * time.Second
+		}
+
+		c.tokenSource = &tokenSource{
+			client: c,
+			config: config,
+			now:    time.Now,
+		}
+		return nil
+	}
+	return f
+}
+
+// defaultTokenLifetime is used for tokens sent back without expires_in, a token revoked
+// before then is still replaced as soon as the api rejects it with 401
+const defaultTokenLifetime = time.Hour
+
+// tokenFetchTimeout bounds a token request, the request is shared by all the goroutines
+// waiting for a token so it is not bound to the context of any of them
+const tokenFetchTimeout = 30 * time.Second
+
+// tokenSource fetches and caches oauth2 access tokens
+type tokenSource struct {
+	client *Client
+	config OAuth2Config
+
+	mu        sync.Mutex
+	token     string
+	refreshAt time.Time
+	flight    *tokenFlight
+
+	now func() time.Time
+}
+
+// tokenFlight is a token request in progress, goroutines needing a token while
+// it is in progress wait for it instead of requesting a token of their own
+type tokenFlight struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// tokenResponse is the body sent back by the token endpoint
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// Token returns the cached token if it is still valid, otherwise it waits for a new one to be
+// fetched. Cancelling ctx only stops the wait of the caller, the token is still fetched and
+// cached for the other callers.
+func (ts *tokenSource) Token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+
+	if ts.token != "" && ts.now().Before(ts.refreshAt) {
+		token := ts.token
+		ts.mu.Unlock()
+		return token, nil
+	}
+
+	// join the token request in progress, if there is none start one
+	flight := ts.flight
+	if flight == nil {
+		flight = &tokenFlight{done: make(chan struct{})}
+		ts.flight = flight
+		go ts.refresh(flight)
+	}
+	ts.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-flight.done:
+	}
+
+	return flight.token, flight.err
+}
+
+// refresh fetches a new token, caches it and hands it over to the goroutines waiting for the flight
+func (ts *tokenSource) refresh(flight *tokenFlight) {
+	ctx, cancel := context.WithTimeout(context.Background(), tokenFetchTimeout)
+	defer cancel()
+
+	token, refreshAt, err := ts.fetch(ctx)
+
+	ts.mu.Lock()
+	if err == nil {
+		ts.token, ts.refreshAt = token, refreshAt
+	}
+	ts.flight = nil
+	flight.token, flight.err = token, err
+	ts.mu.Unlock()
+	close(flight.done)
+}
+
+// invalidate drops the token from the cache if it is still the cached one
+func (ts *tokenSource) invalidate(token string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token == token {
+		ts.token = ""
+	}
+}
+
+// fetch requests a new token from the token endpoint, it returns the token along with
+// the time it has to be refreshed at
+func (ts *tokenSource) fetch(ctx context.Context) (string, time.Time, error) {
+	tokenURL := ts.config.TokenURL
+	if tokenURL == "" {
+		u, err := ts.client.BaseURL.Parse("/v1/oauth2/token")
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		tokenURL = u.String()
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", ts.config.ClientID)
+	form.Set("client_secret", ts.config.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, Post, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.SetBasicAuth(ts.config.ClientID, ts.config.ClientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", ts.client.UserAgent)
+
+	httpResp, bodyBytes, err := ts.client.roundTrip(req)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	if httpResp.StatusCode >= 400 {
+		return "", time.Time{}, newAPIError(httpResp, bodyBytes)
+	}
+
+	tokenResp := new(tokenResponse)
+	err = json.Unmarshal(bodyBytes, tokenResp)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	if tokenResp.AccessToken == "" {
+		return "", time.Time{}, errors.New("token endpoint did not return an access token")
+	}
+
+	lifetime := time.Duration(tokenResp.ExpiresIn) * time.Second
+	if lifetime <= 0 {
+		lifetime = defaultTokenLifetime
+	}
+
+	delta := ts.config.ExpiryDelta
+	if delta > lifetime/2 {
+		delta = lifetime / 2
+	}
+
+	return tokenResp.AccessToken, ts.now().Add(lifetime - delta), nil
+}
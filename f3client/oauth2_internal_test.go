@@ -0,0 +1,71 @@
+package f3client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestTokenSource returns a token source driven by a fake clock that is moved with the returned
+// func, the tokens it fetches expire after expiresIn seconds
+func newTestTokenSource(expiresIn int, expiryDelta time.Duration) (*tokenSource, *int32, func(time.Duration), func()) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"access_token": "token-` + strconv.Itoa(int(n)) + `", "expires_in": ` + strconv.Itoa(expiresIn) + `}`))
+	}))
+
+	c, err := NewClient(WithHostUrl(server.URL), WithOAuth2(OAuth2Config{ClientID: "client-id", ClientSecret: "client-secret", ExpiryDelta: expiryDelta}))
+	if err != nil {
+		panic(err)
+	}
+
+	now := time.Date(2021, 10, 3, 13, 44, 27, 0, time.UTC)
+	ts := c.tokenSource
+	ts.now = func() time.Time { return now }
+
+	return ts, &requests, func(d time.Duration) { now = now.Add(d) }, server.Close
+}
+
+func Test_Unit_TokenSource_RefreshBeforeExpiry(t *testing.T) {
+	ts, requests, advance, closeServer := newTestTokenSource(3600, time.Minute)
+	defer closeServer()
+
+	token, err := ts.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// the token is reused until the expiry delta is reached
+	advance(58 * time.Minute)
+	token, _ = ts.Token(context.Background())
+	assert.Equal(t, "token-1", token)
+
+	advance(time.Minute)
+	token, _ = ts.Token(context.Background())
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+}
+
+func Test_Unit_TokenSource_ExpiryDeltaCappedAtHalfLifetime(t *testing.T) {
+	// the delta is longer than the lifetime of the tokens, half of the lifetime is used instead
+	ts, requests, advance, closeServer := newTestTokenSource(20, time.Minute)
+	defer closeServer()
+
+	token, _ := ts.Token(context.Background())
+	assert.Equal(t, "token-1", token)
+
+	advance(9 * time.Second)
+	token, _ = ts.Token(context.Background())
+	assert.Equal(t, "token-1", token)
+
+	advance(time.Second)
+	token, _ = ts.Token(context.Background())
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+}
@@ -0,0 +1,280 @@
+package f3client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// oauth2Server is a local token endpoint and account api, the api only
+// accepts the token issued last by the token endpoint
+type oauth2Server struct {
+	*httptest.Server
+	tokenRequests int32
+	expiresIn     int
+	tokenDelay    time.Duration
+
+	mu    sync.Mutex
+	token string
+}
+
+func newOAuth2Server(expiresIn int, tokenDelay time.Duration) *oauth2Server {
+	s := &oauth2Server{expiresIn: expiresIn, tokenDelay: tokenDelay}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "client-id" || clientSecret != "client-secret" || r.FormValue("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error_code": "invalid_client", "error_message": "invalid client credentials"}`))
+			return
+		}
+
+		time.Sleep(s.tokenDelay)
+		n := atomic.AddInt32(&s.tokenRequests, 1)
+
+		s.mu.Lock()
+		s.token = "token-" + strconv.Itoa(int(n))
+		token := s.token
+		s.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "` + token + `", "token_type": "bearer", "expires_in": ` + strconv.Itoa(s.expiresIn) + `}`))
+	})
+	mux.HandleFunc("/v1/organisation/accounts/", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		valid := r.Header.Get("Authorization") == "Bearer "+s.token
+		s.mu.Unlock()
+
+		if !valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error_code": "Unauthorized", "error_message": "invalid token"}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "type": "accounts", "attributes": {"country": "GB"}}}`))
+	})
+
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// revoke makes the api reject the current token until a new one is issued
+func (s *oauth2Server) revoke() {
+	s.mu.Lock()
+	s.token = "revoked"
+	s.mu.Unlock()
+}
+
+func testOAuth2Config() f3client.OAuth2Config {
+	return f3client.OAuth2Config{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+	}
+}
+
+func Test_Unit_WithOAuth2_MissingCredentials(t *testing.T) {
+	_, err := f3client.NewClient(f3client.WithOAuth2(f3client.OAuth2Config{ClientID: "client-id"}))
+
+	var targetErr *f3client.ArgumentError
+	assert.ErrorAs(t, err, &targetErr)
+}
+
+func Test_Unit_WithOAuth2_WithSigner(t *testing.T) {
+	signer, err := f3client.NewHTTPSigner("key-id", readTestFile("rsa_private_key.pem"))
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = f3client.NewClient(f3client.WithOAuth2(testOAuth2Config()), f3client.WithSigner(signer))
+
+	var targetErr *f3client.ArgumentError
+	assert.ErrorAs(t, err, &targetErr)
+}
+
+func Test_Unit_OAuth2_TokenIsCached(t *testing.T) {
+	server := newOAuth2Server(3600, 0)
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithOAuth2(testOAuth2Config()))
+	if err != nil {
+		panic(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err = c.Accounts.Fetch(context.Background(), uuid.New())
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&server.tokenRequests))
+}
+
+func Test_Unit_OAuth2_SingleRefreshForConcurrentRequests(t *testing.T) {
+	server := newOAuth2Server(3600, 50*time.Millisecond)
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithOAuth2(testOAuth2Config()))
+	if err != nil {
+		panic(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Accounts.Fetch(context.Background(), uuid.New())
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&server.tokenRequests))
+}
+
+func Test_Unit_OAuth2_TokenWithoutExpiry(t *testing.T) {
+	// the token endpoint does not say when the token expires
+	server := newOAuth2Server(0, 0)
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithOAuth2(testOAuth2Config()))
+	if err != nil {
+		panic(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err = c.Accounts.Fetch(context.Background(), uuid.New())
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&server.tokenRequests))
+}
+
+func Test_Unit_OAuth2_CancelledRequestWhileRefreshing(t *testing.T) {
+	server := newOAuth2Server(3600, 100*time.Millisecond)
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithOAuth2(testOAuth2Config()))
+	if err != nil {
+		panic(err)
+	}
+
+	// the first request starts the token request and gives up while it is in progress
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	var cancelledErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, cancelledErr = c.Accounts.Fetch(ctx, uuid.New())
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+
+	// the second request waits for the same token and is not affected
+	_, err = c.Accounts.Fetch(context.Background(), uuid.New())
+	wg.Wait()
+
+	assert.ErrorIs(t, cancelledErr, context.DeadlineExceeded)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&server.tokenRequests))
+}
+
+func Test_Unit_OAuth2_ShortLivedToken(t *testing.T) {
+	// tokens expire within the expiry delta, the delta is capped so that they are still reused
+	server := newOAuth2Server(10, 0)
+	defer server.Close()
+
+	config := testOAuth2Config()
+	config.ExpiryDelta = time.Minute
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithOAuth2(config))
+	if err != nil {
+		panic(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err = c.Accounts.Fetch(context.Background(), uuid.New())
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&server.tokenRequests))
+}
+
+func Test_Unit_OAuth2_RetryOnUnauthorized(t *testing.T) {
+	server := newOAuth2Server(3600, 0)
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithOAuth2(testOAuth2Config()))
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = c.Accounts.Fetch(context.Background(), uuid.New())
+	assert.NoError(t, err)
+
+	server.revoke()
+
+	_, err = c.Accounts.Fetch(context.Background(), uuid.New())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&server.tokenRequests))
+}
+
+func Test_Unit_OAuth2_InvalidCredentials(t *testing.T) {
+	server := newOAuth2Server(3600, 0)
+	defer server.Close()
+
+	config := testOAuth2Config()
+	config.ClientSecret = "wrong-secret"
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithOAuth2(config))
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = c.Accounts.Fetch(context.Background(), uuid.New())
+
+	var apiErr *f3client.APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+		assert.Equal(t, "invalid client credentials", apiErr.Message)
+	}
+}
+
+func Test_Unit_OAuth2_WithHttpClient(t *testing.T) {
+	server := newOAuth2Server(3600, 0)
+	defer server.Close()
+
+	var requests int32
+	httpClient := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&requests, 1)
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithHttpClient(httpClient), f3client.WithOAuth2(testOAuth2Config()))
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = c.Accounts.Fetch(context.Background(), uuid.New())
+	assert.NoError(t, err)
+
+	// both the token request and the api request went through the custom client
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,245 @@
+package f3client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+type OrganisationService struct {
+	service
+	ObjectType string
+}
+
+// Organisation represents an organisation unit in the form3 organisation section.
+//
+// Organisations form a hierarchy, the OrganisationID of an organisation is the id of its parent.
+// The root organisation is its own parent.
+//
+// See the Organisation Units section of https://api-docs.form3.tech/api.html for
+// more information about fields.
+type Organisation struct {
+	ID             uuid.UUID              `json:"id,omitempty"`
+	Version        int                    `json:"version,omitempty"`
+	OrganisationID uuid.UUID              `json:"organisation_id,omitempty"`
+	CreatedOn      string                 `json:"created_on,omitempty"`
+	ModifiedOn     string                 `json:"modified_on,omitempty"`
+	Attributes     OrganisationAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (o *Organisation) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, o, "type")
+}
+
+func (o Organisation) MarshalJSON() ([]byte, error) {
+	return marshalExtra(o)
+}
+
+type OrganisationAttributes struct {
+	Name string `json:"name,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (oa *OrganisationAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, oa)
+}
+
+func (oa OrganisationAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(oa)
+}
+
+// IsRoot reports whether the organisation is the root of the hierarchy, i.e. it has no parent
+func (o *Organisation) IsRoot() bool {
+	return o.OrganisationID == uuid.Nil || o.OrganisationID == o.ID
+}
+
+// OrganisationListOptions specifies the optional parameters to the OrganisationService.List
+// and OrganisationService.Iterate methods
+type OrganisationListOptions struct {
+	ListOptions
+	Filter OrganisationFilter
+}
+
+// OrganisationFilter narrows down the organisations returned by the list api, only the
+// organisations matching all of the non empty fields are returned
+type OrganisationFilter struct {
+	// ParentID only returns the direct children of the organisation
+	ParentID uuid.UUID
+	Name     string
+}
+
+// values converts the list options into page and filter query parameters
+func (o *OrganisationListOptions) values() url.Values {
+	if o == nil {
+		return url.Values{}
+	}
+
+	v := o.ListOptions.values()
+	if o.Filter.ParentID != uuid.Nil {
+		v.Set("filter[organisation_id]", o.Filter.ParentID.String())
+	}
+	if o.Filter.Name != "" {
+		v.Set("filter[name]", o.Filter.Name)
+	}
+
+	return v
+}
+
+// Create creates an organisation under the parent organisation set in OrganisationID, the
+// organisation is updated in place with the organisation sent back by the api
+func (orgs *OrganisationService) Create(ctx context.Context, organisation *Organisation) error {
+	// validate for mandatory organisation fields before creating new request
+	if organisation.Attributes.Name == "" {
+		return NewArgError("name", "name is mandatory for organisation create request")
+	}
+
+	return orgs.create(ctx, "/v1/organisation/units", orgs.ObjectType, organisation.ID, organisation)
+}
+
+// Fetch gets the organisation unit with the id, see Parent and Children to move through the
+// hierarchy from there
+func (orgs *OrganisationService) Fetch(ctx context.Context, organisationId uuid.UUID) (*Organisation, error) {
+	organisation := new(Organisation)
+
+	err := orgs.fetch(ctx, "/v1/organisation/units/"+organisationId.String(), orgs.ObjectType, organisation)
+	if err != nil {
+		return nil, err
+	}
+
+	return organisation, nil
+}
+
+// List gets a single page of form3 organisation objects
+//
+// The page to be fetched and the filters to be applied are controlled through opts, when opts is nil
+// the first page with the api default page size is returned.
+func (orgs *OrganisationService) List(ctx context.Context, opts *OrganisationListOptions) ([]Organisation, Links, error) {
+	organisations := []Organisation{}
+
+	links, err := orgs.list(ctx, addQuery("/v1/organisation/units", opts.values()), orgs.ObjectType, &organisations)
+	if err != nil {
+		return nil, Links{}, err
+	}
+
+	return organisations, links, nil
+}
+
+// Iterate returns an OrganisationIterator that walks through all the organisations matching the
+// filter starting from the page specified in opts, until there are no more pages left
+func (orgs *OrganisationService) Iterate(opts *OrganisationListOptions) *OrganisationIterator {
+	return &OrganisationIterator{
+		it: newResourceIterator(orgs.client, orgs.ObjectType, addQuery("/v1/organisation/units", opts.values()), nil),
+	}
+}
+
+// Update updates the organisation with the attributes being passed, the organisation
+// is updated in place with the organisation sent back by the api
+//
+// The version of the organisation has to be the current one, otherwise *VersionConflictError is returned.
+func (orgs *OrganisationService) Update(ctx context.Context, organisation *Organisation) error {
+	if organisation.ID == uuid.Nil {
+		return NewArgError("id", "id is mandatory for organisation update request")
+	}
+
+	path := "/v1/organisation/units/" + organisation.ID.String()
+
+	return orgs.update(ctx, path, orgs.ObjectType, organisation.ID, organisation.Version, organisation)
+}
+
+// Parent gets the parent of the organisation, nil is returned for the root organisation
+func (orgs *OrganisationService) Parent(ctx context.Context, organisation *Organisation) (*Organisation, error) {
+	if organisation.IsRoot() {
+		return nil, nil
+	}
+
+	return orgs.Fetch(ctx, organisation.OrganisationID)
+}
+
+// Children gets all the direct children of the organisation, going through all the pages
+func (orgs *OrganisationService) Children(ctx context.Context, organisationId uuid.UUID) ([]Organisation, error) {
+	children := []Organisation{}
+
+	it := orgs.Iterate(&OrganisationListOptions{Filter: OrganisationFilter{ParentID: organisationId}})
+	for it.Next(ctx) {
+		child := it.Organisation()
+		// the root organisation is its own parent, it is not its own child
+		if child.ID != organisationId && child.OrganisationID == organisationId {
+			children = append(children, child)
+		}
+	}
+
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+
+	return children, nil
+}
+
+// Walk calls fn for the organisation and all the organisations below it, parents are visited
+// before their children. depth is 0 for the organisation the walk starts from.
+//
+// The walk stops at the first error returned by fn or encountered while fetching organisations.
+// Every organisation is visited once, one that turns up again below itself is skipped.
+func (orgs *OrganisationService) Walk(ctx context.Context, organisationId uuid.UUID, fn func(organisation Organisation, depth int) error) error {
+	root, err := orgs.Fetch(ctx, organisationId)
+	if err != nil {
+		return err
+	}
+
+	return orgs.walk(ctx, *root, 0, map[uuid.UUID]bool{}, fn)
+}
+
+func (orgs *OrganisationService) walk(ctx context.Context, organisation Organisation, depth int, visited map[uuid.UUID]bool, fn func(Organisation, int) error) error {
+	// a hierarchy with a cycle would otherwise be walked forever
+	if visited[organisation.ID] {
+		return nil
+	}
+	visited[organisation.ID] = true
+
+	err := fn(organisation, depth)
+	if err != nil {
+		return err
+	}
+
+	children, err := orgs.Children(ctx, organisation.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		err = orgs.walk(ctx, child, depth+1, visited, fn)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// OrganisationIterator iterates over the organisations returned by the list api one organisation
+// at a time, fetching the next page whenever the current one is exhausted
+type OrganisationIterator struct {
+	it      resourceIterator
+	current Organisation
+}
+
+// Next advances the iterator to the next organisation, it returns false when there are no
+// more organisations left or an error occurs while fetching a page
+func (oi *OrganisationIterator) Next(ctx context.Context) bool {
+	oi.current = Organisation{}
+	return oi.it.next(ctx) && oi.it.decode(&oi.current)
+}
+
+// Organisation returns the organisation the iterator currently points to
+func (oi *OrganisationIterator) Organisation() Organisation {
+	return oi.current
+}
+
+// Err returns the first error encountered while iterating, if any
+func (oi *OrganisationIterator) Err() error {
+	return oi.it.err
+}
@@ -0,0 +1,280 @@
+package f3client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// test hierarchy, root has the children a and b and a has the child c
+var (
+	rootOrgID = uuid.MustParse("743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb")
+	orgAID    = uuid.MustParse("1a1a1a1a-0000-4000-8000-000000000001")
+	orgBID    = uuid.MustParse("2b2b2b2b-0000-4000-8000-000000000002")
+	orgCID    = uuid.MustParse("3c3c3c3c-0000-4000-8000-000000000003")
+)
+
+// newOrganisationServer mocks the organisation units api for the test hierarchy, the list
+// api returns one organisation per page so that the children have to be paged through
+func newOrganisationServer() *httptest.Server {
+	return newOrganisationServerWith(rootOrgID)
+}
+
+// newOrganisationServerWith mocks the organisation units api for the test hierarchy with
+// rootParentID as the parent of the root organisation
+func newOrganisationServerWith(rootParentID uuid.UUID) *httptest.Server {
+	organisations := []f3client.Organisation{
+		{ID: rootOrgID, OrganisationID: rootParentID, Attributes: f3client.OrganisationAttributes{Name: "root"}},
+		{ID: orgAID, OrganisationID: rootOrgID, Attributes: f3client.OrganisationAttributes{Name: "a"}},
+		{ID: orgBID, OrganisationID: rootOrgID, Attributes: f3client.OrganisationAttributes{Name: "b"}},
+		{ID: orgCID, OrganisationID: orgAID, Attributes: f3client.OrganisationAttributes{Name: "c"}},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := strings.TrimPrefix(r.URL.Path, "/v1/organisation/units/"); id != r.URL.Path {
+			for _, organisation := range organisations {
+				if organisation.ID.String() == id {
+					json.NewEncoder(w).Encode(map[string]interface{}{"data": organisation})
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// the api also returns the root organisation as a child of itself
+		page := []f3client.Organisation{}
+		for _, organisation := range organisations {
+			if organisation.OrganisationID.String() == r.URL.Query().Get("filter[organisation_id]") {
+				page = append(page, organisation)
+			}
+		}
+
+		number := 0
+		fmt.Sscan(r.URL.Query().Get("page[number]"), &number)
+
+		links := map[string]string{}
+		if number+1 < len(page) {
+			next := r.URL.Query()
+			next.Set("page[number]", fmt.Sprint(number+1))
+			links["next"] = r.URL.Path + "?" + next.Encode()
+		}
+		if number < len(page) {
+			page = page[number : number+1]
+		} else {
+			page = page[:0]
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": page, "links": links})
+	}))
+}
+
+func Test_Unit_OrganisationService_Create(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "1a1a1a1a-0000-4000-8000-000000000001", "organisation_id": "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb",
+			"type": "organisations", "version": 0, "attributes": {"name": "a"}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	organisation := &f3client.Organisation{
+		ID:             orgAID,
+		OrganisationID: rootOrgID,
+		Attributes:     f3client.OrganisationAttributes{Name: "a"},
+	}
+
+	err = client.Organisations.Create(context.Background(), organisation)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/organisation/units", actualPath)
+	assert.Equal(t, "organisations", actualBody["data"]["type"])
+	assert.Equal(t, rootOrgID.String(), actualBody["data"]["organisation_id"])
+	assert.False(t, organisation.IsRoot())
+}
+
+func Test_Unit_OrganisationService_Create_MissingName(t *testing.T) {
+	client, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	err = client.Organisations.Create(context.Background(), &f3client.Organisation{ID: orgAID, OrganisationID: rootOrgID})
+
+	var targetErr *f3client.ArgumentError
+	assert.ErrorAs(t, err, &targetErr)
+}
+
+func Test_Unit_OrganisationService_Update(t *testing.T) {
+	var actualMethod, actualPath string
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualMethod = r.Method
+		actualPath = r.URL.Path
+		w.Write([]byte(`{"data": {"id": "1a1a1a1a-0000-4000-8000-000000000001", "organisation_id": "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb",
+			"type": "organisations", "version": 1, "attributes": {"name": "renamed"}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	organisation := &f3client.Organisation{
+		ID:             orgAID,
+		OrganisationID: rootOrgID,
+		Attributes:     f3client.OrganisationAttributes{Name: "renamed"},
+	}
+
+	err = client.Organisations.Update(context.Background(), organisation)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, http.MethodPatch, actualMethod)
+	assert.Equal(t, "/v1/organisation/units/1a1a1a1a-0000-4000-8000-000000000001", actualPath)
+	assert.Equal(t, 1, organisation.Version)
+}
+
+func Test_Unit_OrganisationService_List_Filter(t *testing.T) {
+	var actualQuery string
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualQuery = r.URL.RawQuery
+		w.Write([]byte(`{"data": []}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	organisations, _, err := client.Organisations.List(context.Background(), &f3client.OrganisationListOptions{
+		Filter: f3client.OrganisationFilter{ParentID: rootOrgID, Name: "a"},
+	})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "filter%5Bname%5D=a&filter%5Borganisation_id%5D=743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb", actualQuery)
+	assert.Empty(t, organisations)
+}
+
+func Test_Unit_OrganisationService_Parent(t *testing.T) {
+	server := newOrganisationServer()
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	orgC, err := client.Organisations.Fetch(context.Background(), orgCID)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	parent, err := client.Organisations.Parent(context.Background(), orgC)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, orgAID, parent.ID)
+
+	root, err := client.Organisations.Fetch(context.Background(), rootOrgID)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	parent, err = client.Organisations.Parent(context.Background(), root)
+	assert.NoError(t, err)
+	assert.Nil(t, parent)
+}
+
+func Test_Unit_OrganisationService_Children(t *testing.T) {
+	server := newOrganisationServer()
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	children, err := client.Organisations.Children(context.Background(), rootOrgID)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	if assert.Len(t, children, 2) {
+		assert.Equal(t, orgAID, children[0].ID)
+		assert.Equal(t, orgBID, children[1].ID)
+	}
+}
+
+func Test_Unit_OrganisationService_Walk(t *testing.T) {
+	server := newOrganisationServer()
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	visited := []string{}
+	err = client.Organisations.Walk(context.Background(), rootOrgID, func(organisation f3client.Organisation, depth int) error {
+		visited = append(visited, fmt.Sprintf("%d:%s", depth, organisation.Attributes.Name))
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0:root", "1:a", "2:c", "1:b"}, visited)
+}
+
+func Test_Unit_OrganisationService_Walk_Cycle(t *testing.T) {
+	// root is a child of c, which is below root
+	server := newOrganisationServerWith(orgCID)
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	visited := []string{}
+	err = client.Organisations.Walk(context.Background(), rootOrgID, func(organisation f3client.Organisation, depth int) error {
+		visited = append(visited, fmt.Sprintf("%d:%s", depth, organisation.Attributes.Name))
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0:root", "1:a", "2:c", "1:b"}, visited)
+}
@@ -0,0 +1,92 @@
+package f3client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ListOptions specifies the pagination parameters accepted by form3 list apis
+//
+// Page numbers start from 0, when PageSize is 0 the api default page size is used.
+type ListOptions struct {
+	PageNumber int
+	PageSize   int
+}
+
+// values converts the list options into page[number] and page[size] query parameters
+func (o *ListOptions) values() url.Values {
+	v := url.Values{}
+	if o == nil {
+		return v
+	}
+
+	if o.PageNumber > 0 {
+		v.Set("page[number]", strconv.Itoa(o.PageNumber))
+	}
+	if o.PageSize > 0 {
+		v.Set("page[size]", strconv.Itoa(o.PageSize))
+	}
+
+	return v
+}
+
+// addQuery appends the encoded query parameters to the path
+func addQuery(path string, v url.Values) string {
+	if len(v) == 0 {
+		return path
+	}
+
+	if strings.Contains(path, "?") {
+		return path + "&" + v.Encode()
+	}
+	return path + "?" + v.Encode()
+}
+
+// pager fetches consecutive pages of a list api by following the links.next
+// value of every page returned, until the last page is reached
+type pager struct {
+	client     *Client
+	objectType string
+	next       string
+	done       bool
+}
+
+func newPager(c *Client, objectType, path string) *pager {
+	return &pager{
+		client:     c,
+		objectType: objectType,
+		next:       path,
+	}
+}
+
+// nextPage fetches the next page, once there are no more pages left to fetch
+// the pager is marked as done
+func (p *pager) nextPage(ctx context.Context) (*ListResponse, error) {
+	req, err := p.client.NewRequest(ctx, Get, p.next, p.objectType, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.SendListRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp == nil {
+		p.done = true
+		return &ListResponse{}, nil
+	}
+
+	// stop when the api does not point to any further page or keeps
+	// pointing back to the page that was just fetched
+	if len(resp.Data) == 0 || resp.Links.Next == "" || resp.Links.Next == p.next ||
+		(resp.Links.Self != "" && resp.Links.Self == resp.Links.Last) {
+		p.done = true
+	} else {
+		p.next = resp.Links.Next
+	}
+
+	return resp, nil
+}
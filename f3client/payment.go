@@ -0,0 +1,299 @@
+package f3client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+type PaymentService struct {
+	service
+	ObjectType string
+}
+
+// Payment represents a payment in the form3 transaction section.
+//
+// See the Payments section of https://api-docs.form3.tech/api.html for
+// more information about fields.
+type Payment struct {
+	ID             uuid.UUID         `json:"id,omitempty"`
+	Version        int               `json:"version,omitempty"`
+	OrganisationID uuid.UUID         `json:"organisation_id,omitempty"`
+	CreatedOn      string            `json:"created_on,omitempty"`
+	ModifiedOn     string            `json:"modified_on,omitempty"`
+	Attributes     PaymentAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (p *Payment) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, p, "type")
+}
+
+func (p Payment) MarshalJSON() ([]byte, error) {
+	return marshalExtra(p)
+}
+
+type PaymentAttributes struct {
+	Amount               string              `json:"amount,omitempty"`
+	Currency             string              `json:"currency,omitempty"`
+	BeneficiaryParty     *PaymentParty       `json:"beneficiary_party,omitempty"`
+	DebtorParty          *PaymentParty       `json:"debtor_party,omitempty"`
+	ChargesInformation   *ChargesInformation `json:"charges_information,omitempty"`
+	Fx                   *FxInformation      `json:"fx,omitempty"`
+	EndToEndReference    string              `json:"end_to_end_reference,omitempty"`
+	NumericReference     string              `json:"numeric_reference,omitempty"`
+	PaymentID            string              `json:"payment_id,omitempty"`
+	PaymentPurpose       string              `json:"payment_purpose,omitempty"`
+	PaymentScheme        string              `json:"payment_scheme,omitempty"`
+	PaymentType          string              `json:"payment_type,omitempty"`
+	ProcessingDate       string              `json:"processing_date,omitempty"`
+	Reference            string              `json:"reference,omitempty"`
+	SchemePaymentType    string              `json:"scheme_payment_type,omitempty"`
+	SchemePaymentSubType string              `json:"scheme_payment_sub_type,omitempty"`
+	UniqueSchemeID       string              `json:"unique_scheme_id,omitempty"`
+	Status               string              `json:"status,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (pa *PaymentAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, pa)
+}
+
+func (pa PaymentAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(pa)
+}
+
+// PaymentParty is the beneficiary or the debtor of a payment
+type PaymentParty struct {
+	AccountName       string                `json:"account_name,omitempty"`
+	AccountNumber     string                `json:"account_number,omitempty"`
+	AccountNumberCode string                `json:"account_number_code,omitempty"`
+	AccountType       int                   `json:"account_type,omitempty"`
+	AccountWith       *AccountHoldingEntity `json:"account_with,omitempty"`
+	Address           []string              `json:"address,omitempty"`
+	BirthDate         string                `json:"birth_date,omitempty"`
+	Country           string                `json:"country,omitempty"`
+	Name              string                `json:"name,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (pp *PaymentParty) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, pp)
+}
+
+func (pp PaymentParty) MarshalJSON() ([]byte, error) {
+	return marshalExtra(pp)
+}
+
+// AccountHoldingEntity identifies the bank holding the account of a party
+type AccountHoldingEntity struct {
+	BankID     string `json:"bank_id,omitempty"`
+	BankIDCode string `json:"bank_id_code,omitempty"`
+	Bic        string `json:"bic,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ah *AccountHoldingEntity) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ah)
+}
+
+func (ah AccountHoldingEntity) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ah)
+}
+
+// ChargesInformation describes who bears the charges of a payment and how much they are
+type ChargesInformation struct {
+	BearerCode              string   `json:"bearer_code,omitempty"`
+	SenderCharges           []Charge `json:"sender_charges,omitempty"`
+	ReceiverChargesAmount   string   `json:"receiver_charges_amount,omitempty"`
+	ReceiverChargesCurrency string   `json:"receiver_charges_currency,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ci *ChargesInformation) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ci)
+}
+
+func (ci ChargesInformation) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ci)
+}
+
+// Charge is a single amount charged on a payment
+type Charge struct {
+	Amount   string `json:"amount,omitempty"`
+	Currency string `json:"currency,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (c *Charge) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, c)
+}
+
+func (c Charge) MarshalJSON() ([]byte, error) {
+	return marshalExtra(c)
+}
+
+// FxInformation holds the foreign exchange details of a payment
+type FxInformation struct {
+	ContractReference string `json:"contract_reference,omitempty"`
+	ExchangeRate      string `json:"exchange_rate,omitempty"`
+	OriginalAmount    string `json:"original_amount,omitempty"`
+	OriginalCurrency  string `json:"original_currency,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (fx *FxInformation) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, fx)
+}
+
+func (fx FxInformation) MarshalJSON() ([]byte, error) {
+	return marshalExtra(fx)
+}
+
+// PaymentListOptions specifies the optional parameters to the PaymentService.List
+// and PaymentService.Iterate methods
+type PaymentListOptions struct {
+	ListOptions
+	Filter PaymentFilter
+}
+
+// PaymentFilter narrows down the payments returned by the list api, only the payments
+// matching all of the non empty fields are returned
+type PaymentFilter struct {
+	Currency                      string
+	Amount                        string
+	SchemePaymentType             string
+	PaymentScheme                 string
+	EndToEndReference             string
+	BeneficiaryPartyAccountNumber string
+	DebtorPartyAccountNumber      string
+
+	// ProcessingDateFrom and ProcessingDateTo limit the processing date range, formatted as YYYY-MM-DD
+	ProcessingDateFrom string
+	ProcessingDateTo   string
+}
+
+// values converts the list options into page and filter query parameters
+func (o *PaymentListOptions) values() (url.Values, error) {
+	if o == nil {
+		return url.Values{}, nil
+	}
+
+	// the dates are in YYYY-MM-DD format, so they compare as strings
+	if o.Filter.ProcessingDateFrom != "" && o.Filter.ProcessingDateTo != "" &&
+		o.Filter.ProcessingDateFrom > o.Filter.ProcessingDateTo {
+		return nil, NewArgError("filter", "processing_date_from cannot be after processing_date_to")
+	}
+
+	v := o.ListOptions.values()
+
+	filters := map[string]string{
+		"filter[currency]":                         o.Filter.Currency,
+		"filter[amount]":                           o.Filter.Amount,
+		"filter[scheme_payment_type]":              o.Filter.SchemePaymentType,
+		"filter[payment_scheme]":                   o.Filter.PaymentScheme,
+		"filter[end_to_end_reference]":             o.Filter.EndToEndReference,
+		"filter[beneficiary_party.account_number]": o.Filter.BeneficiaryPartyAccountNumber,
+		"filter[debtor_party.account_number]":      o.Filter.DebtorPartyAccountNumber,
+		"filter[processing_date_from]":             o.Filter.ProcessingDateFrom,
+		"filter[processing_date_to]":               o.Filter.ProcessingDateTo,
+	}
+	for key, value := range filters {
+		if value != "" {
+			v.Set(key, value)
+		}
+	}
+
+	return v, nil
+}
+
+// Create creates a payment using form3 payment api, the payment is updated in place
+// with the payment sent back by the api
+//
+// Creating a payment does not send it, a submission has to be created for that.
+func (ps *PaymentService) Create(ctx context.Context, payment *Payment) error {
+	// validate for mandatory payment fields before creating new request
+	if payment.Attributes.Amount == "" {
+		return NewArgError("amount", "amount is mandatory for payment create request")
+	} else if payment.Attributes.Currency == "" {
+		return NewArgError("currency", "currency is mandatory for payment create request")
+	}
+
+	return ps.create(ctx, "/v1/transaction/payments", ps.ObjectType, payment.ID, payment)
+}
+
+// Fetch gets a single payment by its id, IsNotFound reports true for the returned error when the
+// payment does not exist
+func (ps *PaymentService) Fetch(ctx context.Context, paymentId uuid.UUID) (*Payment, error) {
+	payment := new(Payment)
+
+	err := ps.fetch(ctx, "/v1/transaction/payments/"+paymentId.String(), ps.ObjectType, payment)
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// List gets a single page of form3 payment objects
+//
+// The page to be fetched and the filters to be applied are controlled through opts, when opts is nil
+// the first page with the api default page size is returned.
+func (ps *PaymentService) List(ctx context.Context, opts *PaymentListOptions) ([]Payment, Links, error) {
+	query, err := opts.values()
+	if err != nil {
+		return nil, Links{}, err
+	}
+
+	payments := []Payment{}
+	links, err := ps.list(ctx, addQuery("/v1/transaction/payments", query), ps.ObjectType, &payments)
+	if err != nil {
+		return nil, Links{}, err
+	}
+
+	return payments, links, nil
+}
+
+// Iterate returns a PaymentIterator that walks through all the payments matching the filter
+// starting from the page specified in opts, until there are no more pages left
+//
+// If opts is not valid the iterator does not fetch anything and the error is returned by Err
+func (ps *PaymentService) Iterate(opts *PaymentListOptions) *PaymentIterator {
+	query, err := opts.values()
+
+	return &PaymentIterator{
+		it: newResourceIterator(ps.client, ps.ObjectType, addQuery("/v1/transaction/payments", query), err),
+	}
+}
+
+// PaymentIterator iterates over the payments returned by the list api one payment at a time,
+// fetching the next page whenever the current one is exhausted
+type PaymentIterator struct {
+	it      resourceIterator
+	current Payment
+}
+
+// Next advances the iterator to the next payment, it returns false when there are no
+// more payments left or an error occurs while fetching a page
+func (pi *PaymentIterator) Next(ctx context.Context) bool {
+	pi.current = Payment{}
+	return pi.it.next(ctx) && pi.it.decode(&pi.current)
+}
+
+// Payment returns the payment the iterator currently points to
+func (pi *PaymentIterator) Payment() Payment {
+	return pi.current
+}
+
+// Err returns the first error encountered while iterating, if any
+func (pi *PaymentIterator) Err() error {
+	return pi.it.err
+}
@@ -0,0 +1,272 @@
+package f3client
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// RecallReasonCode is the reason a recall (camt.056) of a payment is raised for
+type RecallReasonCode string
+
+// Recall reason codes accepted by the form3 apis
+const (
+	RecallReasonDuplicatePayment       RecallReasonCode = "DUPL"
+	RecallReasonTechnicalProblem       RecallReasonCode = "TECH"
+	RecallReasonFraudulentOrigin       RecallReasonCode = "FRAD"
+	RecallReasonRequestedByCustomer    RecallReasonCode = "CUST"
+	RecallReasonInvalidCreditorAccount RecallReasonCode = "AC03"
+	RecallReasonWrongAmount            RecallReasonCode = "AM09"
+	RecallReasonUnduePayment           RecallReasonCode = "UPAY"
+)
+
+// IsValid reports whether the reason code is one of the known recall reason codes
+func (c RecallReasonCode) IsValid() bool {
+	switch c {
+	case RecallReasonDuplicatePayment, RecallReasonTechnicalProblem, RecallReasonFraudulentOrigin,
+		RecallReasonRequestedByCustomer, RecallReasonInvalidCreditorAccount, RecallReasonWrongAmount,
+		RecallReasonUnduePayment:
+		return true
+	}
+	return false
+}
+
+// RecallDecisionAnswer is the answer given to a recall
+type RecallDecisionAnswer string
+
+// Answers of a recall decision
+const (
+	RecallDecisionAccepted RecallDecisionAnswer = "accepted"
+	RecallDecisionRejected RecallDecisionAnswer = "rejected"
+)
+
+// RecallRejectionReasonCode is the reason a recall is rejected for
+type RecallRejectionReasonCode string
+
+// Recall rejection reason codes accepted by the form3 apis
+const (
+	RecallRejectionAccountClosed        RecallRejectionReasonCode = "AC04"
+	RecallRejectionInsufficientFunds    RecallRejectionReasonCode = "AM04"
+	RecallRejectionNoAnswerFromCustomer RecallRejectionReasonCode = "NOAS"
+	RecallRejectionNoOriginalPayment    RecallRejectionReasonCode = "NOOR"
+	RecallRejectionAlreadyReturned      RecallRejectionReasonCode = "ARDT"
+	RecallRejectionCustomerDecision     RecallRejectionReasonCode = "CUST"
+	RecallRejectionLegalDecision        RecallRejectionReasonCode = "LEGL"
+)
+
+// IsValid reports whether the reason code is one of the known recall rejection reason codes
+func (c RecallRejectionReasonCode) IsValid() bool {
+	switch c {
+	case RecallRejectionAccountClosed, RecallRejectionInsufficientFunds, RecallRejectionNoAnswerFromCustomer,
+		RecallRejectionNoOriginalPayment, RecallRejectionAlreadyReturned, RecallRejectionCustomerDecision,
+		RecallRejectionLegalDecision:
+		return true
+	}
+	return false
+}
+
+// Recall represents the request to get the funds of a settled payment back.
+//
+// See the Recalls section of https://api-docs.form3.tech/api.html for
+// more information about fields.
+type Recall struct {
+	ID             uuid.UUID        `json:"id,omitempty"`
+	Version        int              `json:"version,omitempty"`
+	OrganisationID uuid.UUID        `json:"organisation_id,omitempty"`
+	CreatedOn      string           `json:"created_on,omitempty"`
+	ModifiedOn     string           `json:"modified_on,omitempty"`
+	Attributes     RecallAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (r *Recall) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, r, "type")
+}
+
+func (r Recall) MarshalJSON() ([]byte, error) {
+	return marshalExtra(r)
+}
+
+type RecallAttributes struct {
+	Reason              RecallReasonCode `json:"reason,omitempty"`
+	ReasonInformation   string           `json:"reason_information,omitempty"`
+	SchemeTransactionID string           `json:"scheme_transaction_id,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ra *RecallAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ra)
+}
+
+func (ra RecallAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ra)
+}
+
+// RecallSubmission represents the submission of a recall to the payment scheme
+type RecallSubmission struct {
+	ID             uuid.UUID                  `json:"id,omitempty"`
+	Version        int                        `json:"version,omitempty"`
+	OrganisationID uuid.UUID                  `json:"organisation_id,omitempty"`
+	CreatedOn      string                     `json:"created_on,omitempty"`
+	ModifiedOn     string                     `json:"modified_on,omitempty"`
+	Attributes     RecallSubmissionAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (rs *RecallSubmission) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, rs, "type")
+}
+
+func (rs RecallSubmission) MarshalJSON() ([]byte, error) {
+	return marshalExtra(rs)
+}
+
+type RecallSubmissionAttributes struct {
+	Status             SubmissionStatus `json:"status,omitempty"`
+	StatusReason       string           `json:"status_reason,omitempty"`
+	SchemeStatusCode   string           `json:"scheme_status_code,omitempty"`
+	SubmissionDatetime string           `json:"submission_datetime,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (rsa *RecallSubmissionAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, rsa)
+}
+
+func (rsa RecallSubmissionAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(rsa)
+}
+
+// RecallDecision represents the answer (camt.029) given to a recall
+type RecallDecision struct {
+	ID             uuid.UUID                `json:"id,omitempty"`
+	Version        int                      `json:"version,omitempty"`
+	OrganisationID uuid.UUID                `json:"organisation_id,omitempty"`
+	CreatedOn      string                   `json:"created_on,omitempty"`
+	ModifiedOn     string                   `json:"modified_on,omitempty"`
+	Attributes     RecallDecisionAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (rd *RecallDecision) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, rd, "type")
+}
+
+func (rd RecallDecision) MarshalJSON() ([]byte, error) {
+	return marshalExtra(rd)
+}
+
+type RecallDecisionAttributes struct {
+	Answer            RecallDecisionAnswer      `json:"answer,omitempty"`
+	RejectReason      RecallRejectionReasonCode `json:"reject_reason,omitempty"`
+	ReasonInformation string                    `json:"reason_information,omitempty"`
+	Status            string                    `json:"status,omitempty"`
+	StatusReason      string                    `json:"status_reason,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (rda *RecallDecisionAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, rda)
+}
+
+func (rda RecallDecisionAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(rda)
+}
+
+// CreateRecall raises a recall for a payment, the recall is updated in place with the recall sent back by the api
+//
+// Creating a recall does not send it, a recall submission has to be created for that.
+func (ps *PaymentService) CreateRecall(ctx context.Context, paymentId uuid.UUID, recall *Recall) error {
+	// validate for mandatory recall fields before creating new request
+	if !recall.Attributes.Reason.IsValid() {
+		return NewArgError("reason", "reason must be one of the recall reason codes")
+	}
+
+	return ps.create(ctx, paymentPath(paymentId)+"/recalls", "recalls", recall.ID, recall)
+}
+
+// FetchRecall gets a recall of a payment
+func (ps *PaymentService) FetchRecall(ctx context.Context, paymentId, recallId uuid.UUID) (*Recall, error) {
+	recall := new(Recall)
+
+	err := ps.fetch(ctx, paymentPath(paymentId)+"/recalls/"+recallId.String(), "recalls", recall)
+	if err != nil {
+		return nil, err
+	}
+
+	return recall, nil
+}
+
+// ListRecalls gets a single page of the recall history of a payment, when opts is nil the first
+// page with the api default page size is returned
+func (ps *PaymentService) ListRecalls(ctx context.Context, paymentId uuid.UUID, opts *ListOptions) ([]Recall, Links, error) {
+	recalls := []Recall{}
+
+	links, err := ps.list(ctx, addQuery(paymentPath(paymentId)+"/recalls", opts.values()), "recalls", &recalls)
+	if err != nil {
+		return nil, Links{}, err
+	}
+
+	return recalls, links, nil
+}
+
+// CreateRecallSubmission submits a recall to the payment scheme, the submission is updated in place
+// with the submission sent back by the api
+func (ps *PaymentService) CreateRecallSubmission(ctx context.Context, paymentId, recallId uuid.UUID, submission *RecallSubmission) error {
+	path := paymentPath(paymentId) + "/recalls/" + recallId.String() + "/submissions"
+
+	return ps.create(ctx, path, "recall_submissions", submission.ID, submission)
+}
+
+// FetchRecallSubmission gets the submission of a recall
+func (ps *PaymentService) FetchRecallSubmission(ctx context.Context, paymentId, recallId, submissionId uuid.UUID) (*RecallSubmission, error) {
+	submission := new(RecallSubmission)
+	path := paymentPath(paymentId) + "/recalls/" + recallId.String() + "/submissions/" + submissionId.String()
+
+	err := ps.fetch(ctx, path, "recall_submissions", submission)
+	if err != nil {
+		return nil, err
+	}
+
+	return submission, nil
+}
+
+// CreateRecallDecision records the answer to a recall received for a payment, the decision is updated
+// in place with the decision sent back by the api
+//
+// A rejected recall has to carry one of the recall rejection reason codes.
+func (ps *PaymentService) CreateRecallDecision(ctx context.Context, paymentId, recallId uuid.UUID, decision *RecallDecision) error {
+	// validate for mandatory decision fields before creating new request
+	switch decision.Attributes.Answer {
+	case RecallDecisionAccepted:
+	case RecallDecisionRejected:
+		if !decision.Attributes.RejectReason.IsValid() {
+			return NewArgError("reject_reason", "reject_reason must be one of the recall rejection reason codes")
+		}
+	default:
+		return NewArgError("answer", "answer must be either accepted or rejected")
+	}
+
+	path := paymentPath(paymentId) + "/recalls/" + recallId.String() + "/decisions"
+
+	return ps.create(ctx, path, "recall_decisions", decision.ID, decision)
+}
+
+// FetchRecallDecision gets the decision taken on a recall
+func (ps *PaymentService) FetchRecallDecision(ctx context.Context, paymentId, recallId, decisionId uuid.UUID) (*RecallDecision, error) {
+	decision := new(RecallDecision)
+	path := paymentPath(paymentId) + "/recalls/" + recallId.String() + "/decisions/" + decisionId.String()
+
+	err := ps.fetch(ctx, path, "recall_decisions", decision)
+	if err != nil {
+		return nil, err
+	}
+
+	return decision, nil
+}
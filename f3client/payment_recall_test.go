@@ -0,0 +1,212 @@
+package f3client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unit_RecallReasonCode_IsValid(t *testing.T) {
+	assert.True(t, f3client.RecallReasonDuplicatePayment.IsValid())
+	assert.True(t, f3client.RecallReasonCode("FRAD").IsValid())
+	assert.False(t, f3client.RecallReasonCode("XXXX").IsValid())
+	assert.False(t, f3client.RecallReasonCode("").IsValid())
+
+	assert.True(t, f3client.RecallRejectionNoAnswerFromCustomer.IsValid())
+	assert.False(t, f3client.RecallRejectionReasonCode("DUPL").IsValid())
+}
+
+func Test_Unit_PaymentService_CreateRecall(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "2d8e6f4b-21a3-4c1e-9f55-7f1a3c1e5b8e", "type": "recalls", "attributes": {"reason": "DUPL", "scheme_transaction_id": "123456789"}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	paymentUUID, _ := uuid.Parse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+
+	recall := &f3client.Recall{
+		ID:             uuid.New(),
+		OrganisationID: uuid.New(),
+		Attributes:     f3client.RecallAttributes{Reason: f3client.RecallReasonDuplicatePayment},
+	}
+
+	err = client.Payments.CreateRecall(context.Background(), paymentUUID, recall)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/payments/4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43/recalls", actualPath)
+	assert.Equal(t, "recalls", actualBody["data"]["type"])
+	assert.Equal(t, "DUPL", actualBody["data"]["attributes"].(map[string]interface{})["reason"])
+	assert.Equal(t, "123456789", recall.Attributes.SchemeTransactionID)
+}
+
+func Test_Unit_PaymentService_CreateRecall_InvalidReason(t *testing.T) {
+	client, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	recall := &f3client.Recall{
+		ID:             uuid.New(),
+		OrganisationID: uuid.New(),
+		Attributes:     f3client.RecallAttributes{Reason: "XXXX"},
+	}
+
+	err = client.Payments.CreateRecall(context.Background(), uuid.New(), recall)
+
+	var targetErr *f3client.ArgumentError
+	assert.ErrorAs(t, err, &targetErr)
+}
+
+func Test_Unit_PaymentService_ListRecalls(t *testing.T) {
+	var actualPath string
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		w.Write([]byte(`{"data": [{"id": "2d8e6f4b-21a3-4c1e-9f55-7f1a3c1e5b8e", "type": "recalls", "attributes": {"reason": "DUPL"}},
+			{"id": "6a0b1c5b-d61d-4a8d-8a0b-3e6c4c3a0a6f", "type": "recalls", "attributes": {"reason": "FRAD"}}]}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	paymentUUID, _ := uuid.Parse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+
+	recalls, _, err := client.Payments.ListRecalls(context.Background(), paymentUUID, nil)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/payments/4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43/recalls", actualPath)
+	if assert.Len(t, recalls, 2) {
+		assert.Equal(t, f3client.RecallReasonFraudulentOrigin, recalls[1].Attributes.Reason)
+	}
+}
+
+func Test_Unit_PaymentService_CreateRecallSubmission(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "7f1a3c1e-5b8e-4f55-9c0b-2d3a8e6f4b21", "type": "recall_submissions", "attributes": {"status": "accepted"}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	paymentUUID, _ := uuid.Parse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+	recallUUID, _ := uuid.Parse("2d8e6f4b-21a3-4c1e-9f55-7f1a3c1e5b8e")
+
+	submission := &f3client.RecallSubmission{ID: uuid.New(), OrganisationID: uuid.New()}
+
+	err = client.Payments.CreateRecallSubmission(context.Background(), paymentUUID, recallUUID, submission)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/payments/4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43/recalls/2d8e6f4b-21a3-4c1e-9f55-7f1a3c1e5b8e/submissions", actualPath)
+	assert.Equal(t, "recall_submissions", actualBody["data"]["type"])
+	assert.Equal(t, f3client.SubmissionStatusAccepted, submission.Attributes.Status)
+}
+
+func Test_Unit_PaymentService_CreateRecallDecision(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "8b9c0d1e-2f3a-4b5c-9d6e-7f8a9b0c1d2e", "type": "recall_decisions", "attributes": {"answer": "rejected", "reject_reason": "NOAS"}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	paymentUUID, _ := uuid.Parse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+	recallUUID, _ := uuid.Parse("2d8e6f4b-21a3-4c1e-9f55-7f1a3c1e5b8e")
+
+	decision := &f3client.RecallDecision{
+		ID:             uuid.New(),
+		OrganisationID: uuid.New(),
+		Attributes: f3client.RecallDecisionAttributes{
+			Answer:       f3client.RecallDecisionRejected,
+			RejectReason: f3client.RecallRejectionNoAnswerFromCustomer,
+		},
+	}
+
+	err = client.Payments.CreateRecallDecision(context.Background(), paymentUUID, recallUUID, decision)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/payments/4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43/recalls/2d8e6f4b-21a3-4c1e-9f55-7f1a3c1e5b8e/decisions", actualPath)
+	assert.Equal(t, "recall_decisions", actualBody["data"]["type"])
+	assert.Equal(t, f3client.RecallDecisionRejected, decision.Attributes.Answer)
+	assert.Equal(t, f3client.RecallRejectionNoAnswerFromCustomer, decision.Attributes.RejectReason)
+}
+
+func Test_Unit_PaymentService_CreateRecallDecision_Invalid(t *testing.T) {
+	client, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	cases := map[string]f3client.RecallDecisionAttributes{
+		"no answer":         {},
+		"unknown answer":    {Answer: "maybe"},
+		"no reject reason":  {Answer: f3client.RecallDecisionRejected},
+		"bad reject reason": {Answer: f3client.RecallDecisionRejected, RejectReason: "DUPL"},
+	}
+
+	for name, attributes := range cases {
+		decision := &f3client.RecallDecision{ID: uuid.New(), OrganisationID: uuid.New(), Attributes: attributes}
+
+		err = client.Payments.CreateRecallDecision(context.Background(), uuid.New(), uuid.New(), decision)
+
+		var targetErr *f3client.ArgumentError
+		assert.ErrorAs(t, err, &targetErr, name)
+	}
+}
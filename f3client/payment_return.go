@@ -0,0 +1,277 @@
+package f3client
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Return represents the return of an inbound payment back to the debtor.
+//
+// See the Returns section of https://api-docs.form3.tech/api.html for
+// more information about fields.
+type Return struct {
+	ID             uuid.UUID        `json:"id,omitempty"`
+	Version        int              `json:"version,omitempty"`
+	OrganisationID uuid.UUID        `json:"organisation_id,omitempty"`
+	CreatedOn      string           `json:"created_on,omitempty"`
+	ModifiedOn     string           `json:"modified_on,omitempty"`
+	Attributes     ReturnAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (r *Return) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, r, "type")
+}
+
+func (r Return) MarshalJSON() ([]byte, error) {
+	return marshalExtra(r)
+}
+
+type ReturnAttributes struct {
+	ReturnCode          string `json:"return_code,omitempty"`
+	Amount              string `json:"amount,omitempty"`
+	Currency            string `json:"currency,omitempty"`
+	SchemeTransactionID string `json:"scheme_transaction_id,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ra *ReturnAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ra)
+}
+
+func (ra ReturnAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ra)
+}
+
+// ReturnSubmission represents the submission of a return to the payment scheme
+type ReturnSubmission struct {
+	ID             uuid.UUID                  `json:"id,omitempty"`
+	Version        int                        `json:"version,omitempty"`
+	OrganisationID uuid.UUID                  `json:"organisation_id,omitempty"`
+	CreatedOn      string                     `json:"created_on,omitempty"`
+	ModifiedOn     string                     `json:"modified_on,omitempty"`
+	Attributes     ReturnSubmissionAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (rs *ReturnSubmission) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, rs, "type")
+}
+
+func (rs ReturnSubmission) MarshalJSON() ([]byte, error) {
+	return marshalExtra(rs)
+}
+
+type ReturnSubmissionAttributes struct {
+	Status             SubmissionStatus `json:"status,omitempty"`
+	StatusReason       string           `json:"status_reason,omitempty"`
+	SchemeStatusCode   string           `json:"scheme_status_code,omitempty"`
+	SettlementDate     string           `json:"settlement_date,omitempty"`
+	SettlementCycle    int              `json:"settlement_cycle,omitempty"`
+	SubmissionDatetime string           `json:"submission_datetime,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (rsa *ReturnSubmissionAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, rsa)
+}
+
+func (rsa ReturnSubmissionAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(rsa)
+}
+
+// Reversal represents the reversal of an outbound payment, i.e. the request to recall it
+// before it has been settled.
+//
+// See the Reversals section of https://api-docs.form3.tech/api.html for
+// more information about fields.
+type Reversal struct {
+	ID             uuid.UUID          `json:"id,omitempty"`
+	Version        int                `json:"version,omitempty"`
+	OrganisationID uuid.UUID          `json:"organisation_id,omitempty"`
+	CreatedOn      string             `json:"created_on,omitempty"`
+	ModifiedOn     string             `json:"modified_on,omitempty"`
+	Attributes     ReversalAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (r *Reversal) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, r, "type")
+}
+
+func (r Reversal) MarshalJSON() ([]byte, error) {
+	return marshalExtra(r)
+}
+
+type ReversalAttributes struct {
+	Description         string `json:"description,omitempty"`
+	SchemeTransactionID string `json:"scheme_transaction_id,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ra *ReversalAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ra)
+}
+
+func (ra ReversalAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ra)
+}
+
+// ReversalAdmission represents the admission of a reversal received for an inbound payment
+type ReversalAdmission struct {
+	ID             uuid.UUID                   `json:"id,omitempty"`
+	Version        int                         `json:"version,omitempty"`
+	OrganisationID uuid.UUID                   `json:"organisation_id,omitempty"`
+	CreatedOn      string                      `json:"created_on,omitempty"`
+	ModifiedOn     string                      `json:"modified_on,omitempty"`
+	Attributes     ReversalAdmissionAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ra *ReversalAdmission) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ra, "type")
+}
+
+func (ra ReversalAdmission) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ra)
+}
+
+type ReversalAdmissionAttributes struct {
+	Status           string `json:"status,omitempty"`
+	StatusReason     string `json:"status_reason,omitempty"`
+	SchemeStatusCode string `json:"scheme_status_code,omitempty"`
+	SettlementDate   string `json:"settlement_date,omitempty"`
+	SettlementCycle  int    `json:"settlement_cycle,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (raa *ReversalAdmissionAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, raa)
+}
+
+func (raa ReversalAdmissionAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(raa)
+}
+
+// paymentPath returns the path of a payment, the sub resources of the payment live under it
+func paymentPath(paymentId uuid.UUID) string {
+	return "/v1/transaction/payments/" + paymentId.String()
+}
+
+// CreateReturn returns an inbound payment, the return is updated in place with the return sent back by the api
+//
+// Creating a return does not send it, a return submission has to be created for that.
+func (ps *PaymentService) CreateReturn(ctx context.Context, paymentId uuid.UUID, ret *Return) error {
+	// validate for mandatory return fields before creating new request
+	if ret.Attributes.ReturnCode == "" {
+		return NewArgError("return_code", "return_code is mandatory for return create request")
+	}
+
+	return ps.create(ctx, paymentPath(paymentId)+"/returns", "returns", ret.ID, ret)
+}
+
+// FetchReturn gets a return of a payment
+func (ps *PaymentService) FetchReturn(ctx context.Context, paymentId, returnId uuid.UUID) (*Return, error) {
+	ret := new(Return)
+
+	err := ps.fetch(ctx, paymentPath(paymentId)+"/returns/"+returnId.String(), "returns", ret)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// ListReturns gets a single page of the returns of a payment, when opts is nil the first
+// page with the api default page size is returned
+func (ps *PaymentService) ListReturns(ctx context.Context, paymentId uuid.UUID, opts *ListOptions) ([]Return, Links, error) {
+	returns := []Return{}
+
+	links, err := ps.list(ctx, addQuery(paymentPath(paymentId)+"/returns", opts.values()), "returns", &returns)
+	if err != nil {
+		return nil, Links{}, err
+	}
+
+	return returns, links, nil
+}
+
+// CreateReturnSubmission submits a return to the payment scheme, the submission is updated in place
+// with the submission sent back by the api
+func (ps *PaymentService) CreateReturnSubmission(ctx context.Context, paymentId, returnId uuid.UUID, submission *ReturnSubmission) error {
+	path := paymentPath(paymentId) + "/returns/" + returnId.String() + "/submissions"
+
+	return ps.create(ctx, path, "return_submissions", submission.ID, submission)
+}
+
+// FetchReturnSubmission gets the submission of a return
+func (ps *PaymentService) FetchReturnSubmission(ctx context.Context, paymentId, returnId, submissionId uuid.UUID) (*ReturnSubmission, error) {
+	submission := new(ReturnSubmission)
+	path := paymentPath(paymentId) + "/returns/" + returnId.String() + "/submissions/" + submissionId.String()
+
+	err := ps.fetch(ctx, path, "return_submissions", submission)
+	if err != nil {
+		return nil, err
+	}
+
+	return submission, nil
+}
+
+// CreateReversal reverses an outbound payment, the reversal is updated in place with the reversal sent back by the api
+func (ps *PaymentService) CreateReversal(ctx context.Context, paymentId uuid.UUID, reversal *Reversal) error {
+	return ps.create(ctx, paymentPath(paymentId)+"/reversals", "reversals", reversal.ID, reversal)
+}
+
+// FetchReversal gets a reversal of a payment
+func (ps *PaymentService) FetchReversal(ctx context.Context, paymentId, reversalId uuid.UUID) (*Reversal, error) {
+	reversal := new(Reversal)
+
+	err := ps.fetch(ctx, paymentPath(paymentId)+"/reversals/"+reversalId.String(), "reversals", reversal)
+	if err != nil {
+		return nil, err
+	}
+
+	return reversal, nil
+}
+
+// ListReversals gets a single page of the reversals of a payment, when opts is nil the first
+// page with the api default page size is returned
+func (ps *PaymentService) ListReversals(ctx context.Context, paymentId uuid.UUID, opts *ListOptions) ([]Reversal, Links, error) {
+	reversals := []Reversal{}
+
+	links, err := ps.list(ctx, addQuery(paymentPath(paymentId)+"/reversals", opts.values()), "reversals", &reversals)
+	if err != nil {
+		return nil, Links{}, err
+	}
+
+	return reversals, links, nil
+}
+
+// CreateReversalAdmission admits a reversal received for an inbound payment, the admission is updated
+// in place with the admission sent back by the api
+func (ps *PaymentService) CreateReversalAdmission(ctx context.Context, paymentId, reversalId uuid.UUID, admission *ReversalAdmission) error {
+	path := paymentPath(paymentId) + "/reversals/" + reversalId.String() + "/admissions"
+
+	return ps.create(ctx, path, "reversal_admissions", admission.ID, admission)
+}
+
+// FetchReversalAdmission gets the admission of a reversal
+func (ps *PaymentService) FetchReversalAdmission(ctx context.Context, paymentId, reversalId, admissionId uuid.UUID) (*ReversalAdmission, error) {
+	admission := new(ReversalAdmission)
+	path := paymentPath(paymentId) + "/reversals/" + reversalId.String() + "/admissions/" + admissionId.String()
+
+	err := ps.fetch(ctx, path, "reversal_admissions", admission)
+	if err != nil {
+		return nil, err
+	}
+
+	return admission, nil
+}
@@ -0,0 +1,238 @@
+package f3client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const returnResponse = `
+	{
+		"data": {
+			"attributes": {"amount": "100.21", "currency": "GBP", "return_code": "AC01"},
+			"id": "0b1c5bd6-1d8a-4d1d-8a0b-6c4c3a0a6f3e",
+			"organisation_id": "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb",
+			"type": "returns",
+			"version": 0
+		}
+	}`
+
+func Test_Unit_PaymentService_CreateReturn(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(returnResponse))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	paymentUUID, _ := uuid.Parse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+	returnUUID, _ := uuid.Parse("0b1c5bd6-1d8a-4d1d-8a0b-6c4c3a0a6f3e")
+	organisationUUID, _ := uuid.Parse("743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb")
+
+	ret := &f3client.Return{
+		ID:             returnUUID,
+		OrganisationID: organisationUUID,
+		Attributes:     f3client.ReturnAttributes{ReturnCode: "AC01"},
+	}
+
+	err = client.Payments.CreateReturn(context.Background(), paymentUUID, ret)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/payments/4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43/returns", actualPath)
+	assert.Equal(t, "returns", actualBody["data"]["type"])
+	assert.Equal(t, "100.21", ret.Attributes.Amount)
+	assert.Equal(t, "GBP", ret.Attributes.Currency)
+}
+
+func Test_Unit_PaymentService_CreateReturn_MissingReturnCode(t *testing.T) {
+	client, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	err = client.Payments.CreateReturn(context.Background(), uuid.New(), &f3client.Return{ID: uuid.New(), OrganisationID: uuid.New()})
+
+	var targetErr *f3client.ArgumentError
+	assert.ErrorAs(t, err, &targetErr)
+}
+
+func Test_Unit_PaymentService_FetchReturn(t *testing.T) {
+	var actualPath string
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		w.Write([]byte(returnResponse))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	paymentUUID, _ := uuid.Parse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+	returnUUID, _ := uuid.Parse("0b1c5bd6-1d8a-4d1d-8a0b-6c4c3a0a6f3e")
+
+	ret, err := client.Payments.FetchReturn(context.Background(), paymentUUID, returnUUID)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/payments/4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43/returns/0b1c5bd6-1d8a-4d1d-8a0b-6c4c3a0a6f3e", actualPath)
+	assert.Equal(t, returnUUID, ret.ID)
+	assert.Equal(t, "AC01", ret.Attributes.ReturnCode)
+}
+
+func Test_Unit_PaymentService_ListReturns(t *testing.T) {
+	var actualURL string
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualURL = r.URL.String()
+		w.Write([]byte(`{"data": [{"id": "0b1c5bd6-1d8a-4d1d-8a0b-6c4c3a0a6f3e", "type": "returns", "attributes": {"return_code": "AC01"}},
+			{"id": "5d1f4b8e-0e5c-4a7f-9f7a-1f3c2b6a9d10", "type": "returns", "attributes": {"return_code": "AC04"}}], "links": {"self": "/v1/transaction/payments"}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	paymentUUID, _ := uuid.Parse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+
+	returns, _, err := client.Payments.ListReturns(context.Background(), paymentUUID, &f3client.ListOptions{PageSize: 2})
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/payments/4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43/returns?page%5Bsize%5D=2", actualURL)
+	if assert.Len(t, returns, 2) {
+		assert.Equal(t, "AC04", returns[1].Attributes.ReturnCode)
+	}
+}
+
+func Test_Unit_PaymentService_CreateReturnSubmission(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "7f1a3c1e-5b8e-4f55-9c0b-2d3a8e6f4b21", "type": "return_submissions", "attributes": {"status": "accepted"}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	paymentUUID, _ := uuid.Parse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+	returnUUID, _ := uuid.Parse("0b1c5bd6-1d8a-4d1d-8a0b-6c4c3a0a6f3e")
+
+	submission := &f3client.ReturnSubmission{ID: uuid.New(), OrganisationID: uuid.New()}
+
+	err = client.Payments.CreateReturnSubmission(context.Background(), paymentUUID, returnUUID, submission)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/payments/4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43/returns/0b1c5bd6-1d8a-4d1d-8a0b-6c4c3a0a6f3e/submissions", actualPath)
+	assert.Equal(t, "return_submissions", actualBody["data"]["type"])
+	assert.Equal(t, f3client.SubmissionStatusAccepted, submission.Attributes.Status)
+}
+
+func Test_Unit_PaymentService_CreateReversal(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "9a2b3c4d-1e2f-4a5b-8c7d-6e5f4a3b2c1d", "type": "reversals", "attributes": {"description": "Sent in error"}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	paymentUUID, _ := uuid.Parse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+
+	reversal := &f3client.Reversal{ID: uuid.New(), OrganisationID: uuid.New()}
+
+	err = client.Payments.CreateReversal(context.Background(), paymentUUID, reversal)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/payments/4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43/reversals", actualPath)
+	assert.Equal(t, "reversals", actualBody["data"]["type"])
+	assert.Equal(t, "Sent in error", reversal.Attributes.Description)
+}
+
+func Test_Unit_PaymentService_FetchReversalAdmission(t *testing.T) {
+	var actualPath string
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		w.Write([]byte(`{"data": {"id": "3c2b1a0f-9e8d-4c7b-a6f5-e4d3c2b1a0f9", "type": "reversal_admissions", "attributes": {"status": "confirmed", "settlement_cycle": 1}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	paymentUUID, _ := uuid.Parse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+	reversalUUID, _ := uuid.Parse("9a2b3c4d-1e2f-4a5b-8c7d-6e5f4a3b2c1d")
+	admissionUUID, _ := uuid.Parse("3c2b1a0f-9e8d-4c7b-a6f5-e4d3c2b1a0f9")
+
+	admission, err := client.Payments.FetchReversalAdmission(context.Background(), paymentUUID, reversalUUID, admissionUUID)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/payments/4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43/reversals/9a2b3c4d-1e2f-4a5b-8c7d-6e5f4a3b2c1d/admissions/3c2b1a0f-9e8d-4c7b-a6f5-e4d3c2b1a0f9", actualPath)
+	assert.Equal(t, "confirmed", admission.Attributes.Status)
+	assert.Equal(t, 1, admission.Attributes.SettlementCycle)
+}
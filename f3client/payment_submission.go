@@ -0,0 +1,131 @@
+package f3client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// SubmissionStatus is the status of a submission as it goes through the payment scheme
+type SubmissionStatus string
+
+// Submission statuses reported by the form3 apis
+const (
+	SubmissionStatusAccepted          SubmissionStatus = "accepted"
+	SubmissionStatusValidationPending SubmissionStatus = "validation_pending"
+	SubmissionStatusValidationFailed  SubmissionStatus = "validation_failed"
+	SubmissionStatusQueuedForDelivery SubmissionStatus = "queued_for_delivery"
+	SubmissionStatusReleasedToGateway SubmissionStatus = "released_to_gateway"
+	SubmissionStatusDeliveryConfirmed SubmissionStatus = "delivery_confirmed"
+	SubmissionStatusDeliveryFailed    SubmissionStatus = "delivery_failed"
+)
+
+// IsTerminal reports whether the submission has reached a status it will not move on from
+func (s SubmissionStatus) IsTerminal() bool {
+	switch s {
+	case SubmissionStatusDeliveryConfirmed, SubmissionStatusDeliveryFailed, SubmissionStatusValidationFailed:
+		return true
+	}
+	return false
+}
+
+// IsFailed reports whether the submission has failed validation or delivery
+func (s SubmissionStatus) IsFailed() bool {
+	return s == SubmissionStatusDeliveryFailed || s == SubmissionStatusValidationFailed
+}
+
+// PaymentSubmission represents the submission of a payment to the payment scheme.
+//
+// See the Payment Submissions section of https://api-docs.form3.tech/api.html for
+// more information about fields.
+type PaymentSubmission struct {
+	ID             uuid.UUID                   `json:"id,omitempty"`
+	Version        int                         `json:"version,omitempty"`
+	OrganisationID uuid.UUID                   `json:"organisation_id,omitempty"`
+	CreatedOn      string                      `json:"created_on,omitempty"`
+	ModifiedOn     string                      `json:"modified_on,omitempty"`
+	Attributes     PaymentSubmissionAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ps *PaymentSubmission) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ps, "type")
+}
+
+func (ps PaymentSubmission) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ps)
+}
+
+type PaymentSubmissionAttributes struct {
+	Status                  SubmissionStatus `json:"status,omitempty"`
+	StatusReason            string           `json:"status_reason,omitempty"`
+	SchemeStatusCode        string           `json:"scheme_status_code,omitempty"`
+	SchemeStatusCodeDesc    string           `json:"scheme_status_code_description,omitempty"`
+	SettlementDate          string           `json:"settlement_date,omitempty"`
+	SettlementCycle         int              `json:"settlement_cycle,omitempty"`
+	SubmissionDatetime      string           `json:"submission_datetime,omitempty"`
+	RedirectedBankID        string           `json:"redirected_bank_id,omitempty"`
+	RedirectedAccountNumber string           `json:"redirected_account_number,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (psa *PaymentSubmissionAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, psa)
+}
+
+func (psa PaymentSubmissionAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(psa)
+}
+
+// CreateSubmission submits a payment to the payment scheme, the submission is updated in place
+// with the submission sent back by the api
+//
+// ID and OrganisationID of the submission are mandatory.
+func (ps *PaymentService) CreateSubmission(ctx context.Context, paymentId uuid.UUID, submission *PaymentSubmission) error {
+	path := paymentPath(paymentId) + "/submissions"
+
+	return ps.create(ctx, path, "payment_submissions", submission.ID, submission)
+}
+
+// FetchSubmission gets the submission of a payment
+func (ps *PaymentService) FetchSubmission(ctx context.Context, paymentId, submissionId uuid.UUID) (*PaymentSubmission, error) {
+	submission := new(PaymentSubmission)
+	path := paymentPath(paymentId) + "/submissions/" + submissionId.String()
+
+	err := ps.fetch(ctx, path, "payment_submissions", submission)
+	if err != nil {
+		return nil, err
+	}
+
+	return submission, nil
+}
+
+// WaitForSubmission polls the submission of a payment until it reaches a terminal status, i.e.
+// delivery_confirmed, delivery_failed or validation_failed, and returns it
+//
+// Reaching a failed status is not an error, the status and status reason of the returned submission
+// have to be checked. If ctx is done before the submission reaches a terminal status, the last
+// submission fetched is returned along with an error wrapping ctx.Err(). When opts is nil the
+// DefaultPollOptions are used.
+func (ps *PaymentService) WaitForSubmission(ctx context.Context, paymentId, submissionId uuid.UUID, opts *PollOptions) (*PaymentSubmission, error) {
+	var submission *PaymentSubmission
+
+	err := poll(ctx, opts, func() (bool, error) {
+		fetched, err := ps.FetchSubmission(ctx, paymentId, submissionId)
+		if err != nil {
+			return false, err
+		}
+
+		submission = fetched
+		return submission.Attributes.Status.IsTerminal(), nil
+	})
+
+	if err != nil && ctx.Err() != nil && submission != nil {
+		return submission, fmt.Errorf("submission %s is still %s : %w", submissionId.String(), submission.Attributes.Status, ctx.Err())
+	}
+
+	return submission, err
+}
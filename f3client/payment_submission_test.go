@@ -0,0 +1,206 @@
+package f3client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// submissionResponse returns the json of a submission in the given status
+func submissionResponse(status, statusReason string) []byte {
+	return []byte(`{"data": {"id": "7f1a3c1e-5b8e-4f55-9c0b-2d3a8e6f4b21", "organisation_id": "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb",
+		"type": "payment_submissions", "version": 0,
+		"attributes": {"status": "` + status + `", "status_reason": "` + statusReason + `", "submission_datetime": "2021-10-03T13:44:27.809Z"}}}`)
+}
+
+// fastPoll polls quickly so that the tests do not have to wait
+var fastPoll = &f3client.PollOptions{Interval: time.Millisecond, MaxInterval: 5 * time.Millisecond}
+
+func Test_Unit_SubmissionStatus_IsTerminal(t *testing.T) {
+	assert.True(t, f3client.SubmissionStatusDeliveryConfirmed.IsTerminal())
+	assert.True(t, f3client.SubmissionStatusDeliveryFailed.IsTerminal())
+	assert.True(t, f3client.SubmissionStatusValidationFailed.IsTerminal())
+	assert.False(t, f3client.SubmissionStatusAccepted.IsTerminal())
+	assert.False(t, f3client.SubmissionStatusReleasedToGateway.IsTerminal())
+
+	assert.True(t, f3client.SubmissionStatusDeliveryFailed.IsFailed())
+	assert.False(t, f3client.SubmissionStatusDeliveryConfirmed.IsFailed())
+}
+
+func Test_Unit_PaymentService_CreateSubmission(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(submissionResponse("accepted", ""))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	paymentUUID, _ := uuid.Parse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+	submissionUUID, _ := uuid.Parse("7f1a3c1e-5b8e-4f55-9c0b-2d3a8e6f4b21")
+	organisationUUID, _ := uuid.Parse("743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb")
+
+	submission := &f3client.PaymentSubmission{
+		ID:             submissionUUID,
+		OrganisationID: organisationUUID,
+	}
+
+	err = client.Payments.CreateSubmission(context.Background(), paymentUUID, submission)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/payments/4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43/submissions", actualPath)
+	assert.Equal(t, "payment_submissions", actualBody["data"]["type"])
+	assert.Equal(t, f3client.SubmissionStatusAccepted, submission.Attributes.Status)
+}
+
+func Test_Unit_PaymentService_FetchSubmission(t *testing.T) {
+	var actualPath string
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		w.Write(submissionResponse("delivery_failed", "Account closed"))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	paymentUUID, _ := uuid.Parse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+	submissionUUID, _ := uuid.Parse("7f1a3c1e-5b8e-4f55-9c0b-2d3a8e6f4b21")
+
+	submission, err := client.Payments.FetchSubmission(context.Background(), paymentUUID, submissionUUID)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/payments/4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43/submissions/7f1a3c1e-5b8e-4f55-9c0b-2d3a8e6f4b21", actualPath)
+	assert.Equal(t, f3client.SubmissionStatusDeliveryFailed, submission.Attributes.Status)
+	assert.Equal(t, "Account closed", submission.Attributes.StatusReason)
+}
+
+func Test_Unit_PaymentService_WaitForSubmission(t *testing.T) {
+	var calls int32
+
+	// the submission moves through the statuses on every fetch
+	statuses := []string{"accepted", "released_to_gateway", "delivery_confirmed"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		w.Write(submissionResponse(statuses[n-1], ""))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	submission, err := client.Payments.WaitForSubmission(context.Background(), uuid.New(), uuid.New(), fastPoll)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, f3client.SubmissionStatusDeliveryConfirmed, submission.Attributes.Status)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func Test_Unit_PaymentService_WaitForSubmission_Deadline(t *testing.T) {
+	// the submission never leaves the gateway
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(submissionResponse("released_to_gateway", ""))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	submission, err := client.Payments.WaitForSubmission(ctx, uuid.New(), uuid.New(), fastPoll)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	if assert.NotNil(t, submission) {
+		assert.Equal(t, f3client.SubmissionStatusReleasedToGateway, submission.Attributes.Status)
+	}
+}
+
+func Test_Unit_PaymentService_WaitForSubmission_ZeroInterval(t *testing.T) {
+	var calls int32
+
+	// the submission never leaves the gateway
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write(submissionResponse("released_to_gateway", ""))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = client.Payments.WaitForSubmission(ctx, uuid.New(), uuid.New(), &f3client.PollOptions{Interval: 0})
+
+	// the default interval is used, so the submission is only fetched once before the deadline
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func Test_Unit_PaymentService_WaitForSubmission_NotFound(t *testing.T) {
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error_code": "Not Found", "error_message": "Submission Not Found"}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	submission, err := client.Payments.WaitForSubmission(context.Background(), uuid.New(), uuid.New(), fastPoll)
+
+	assert.Nil(t, submission)
+	assert.True(t, f3client.IsNotFound(err))
+}
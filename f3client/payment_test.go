@@ -0,0 +1,308 @@
+package f3client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const paymentResponse = `
+	{
+		"data": {
+			"attributes": {
+				"amount": "100.21",
+				"currency": "GBP",
+				"beneficiary_party": {
+					"account_name": "W Owens",
+					"account_number": "31926819",
+					"account_number_code": "BBAN",
+					"account_type": 0,
+					"account_with": {"bank_id": "403000", "bank_id_code": "GBDSC"},
+					"address": ["1 The Beneficiary Localtown SE2"],
+					"name": "Wilfred Jeremiah Owens"
+				},
+				"debtor_party": {
+					"account_name": "EJ Brown Black",
+					"account_number": "GB29XABC10161234567801",
+					"account_number_code": "IBAN",
+					"account_with": {"bank_id": "203301", "bank_id_code": "GBDSC"},
+					"name": "Emelia Jane Brown"
+				},
+				"charges_information": {
+					"bearer_code": "SHAR",
+					"sender_charges": [{"amount": "5.00", "currency": "GBP"}, {"amount": "10.00", "currency": "USD"}],
+					"receiver_charges_amount": "1.00",
+					"receiver_charges_currency": "USD"
+				},
+				"fx": {
+					"contract_reference": "FX123",
+					"exchange_rate": "2.00000",
+					"original_amount": "200.42",
+					"original_currency": "USD"
+				},
+				"end_to_end_reference": "Wil piano Jan",
+				"processing_date": "2021-10-03",
+				"scheme_payment_type": "ImmediatePayment",
+				"scheme_payment_sub_type": "InternetBanking"
+			},
+			"created_on": "2021-10-03T13:44:27.809Z",
+			"id": "4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43",
+			"modified_on": "2021-10-03T13:44:27.809Z",
+			"organisation_id": "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb",
+			"type": "payments",
+			"version": 0
+		}
+	}`
+
+func Test_Unit_PaymentService_Create(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(paymentResponse))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	paymentUUID, _ := uuid.Parse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+	organisationUUID, _ := uuid.Parse("743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb")
+
+	actual := &f3client.Payment{
+		ID:             paymentUUID,
+		OrganisationID: organisationUUID,
+		Attributes: f3client.PaymentAttributes{
+			Amount:   "100.21",
+			Currency: "GBP",
+			BeneficiaryParty: &f3client.PaymentParty{
+				AccountNumber: "31926819",
+				AccountWith:   &f3client.AccountHoldingEntity{BankID: "403000", BankIDCode: "GBDSC"},
+			},
+		},
+	}
+
+	err = client.Payments.Create(context.Background(), actual)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/transaction/payments", actualPath)
+	assert.Equal(t, "payments", actualBody["data"]["type"])
+	assert.Equal(t, "Wilfred Jeremiah Owens", actual.Attributes.BeneficiaryParty.Name)
+	assert.Equal(t, "GB29XABC10161234567801", actual.Attributes.DebtorParty.AccountNumber)
+	assert.Equal(t, []f3client.Charge{{Amount: "5.00", Currency: "GBP"}, {Amount: "10.00", Currency: "USD"}}, actual.Attributes.ChargesInformation.SenderCharges)
+	assert.Equal(t, "2.00000", actual.Attributes.Fx.ExchangeRate)
+	assert.Equal(t, "2021-10-03", actual.Attributes.ProcessingDate)
+}
+
+func Test_Unit_PaymentService_Create_RequestValidationFailed_Amount(t *testing.T) {
+	client, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	payment := &f3client.Payment{
+		ID:             uuid.New(),
+		OrganisationID: uuid.New(),
+		Attributes: f3client.PaymentAttributes{
+			Currency: "GBP",
+		},
+	}
+
+	err = client.Payments.Create(context.Background(), payment)
+
+	assert.IsType(t, &f3client.ArgumentError{}, err)
+	assert.EqualError(t, err, "amount : amount is mandatory for payment create request")
+}
+
+func Test_Unit_PaymentService_Fetch(t *testing.T) {
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(paymentResponse))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	paymentUUID, _ := uuid.Parse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+
+	actual, err := client.Payments.Fetch(context.Background(), paymentUUID)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, paymentUUID, actual.ID)
+	assert.Equal(t, "100.21", actual.Attributes.Amount)
+	assert.Equal(t, "SHAR", actual.Attributes.ChargesInformation.BearerCode)
+}
+
+func Test_Unit_PaymentService_Fetch_NotFound(t *testing.T) {
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error_code": "Not Found", "error_message": "Payment Not Found"}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	actual, err := client.Payments.Fetch(context.Background(), uuid.New())
+
+	assert.Nil(t, actual)
+	assert.True(t, f3client.IsNotFound(err))
+}
+
+func Test_Unit_PaymentService_List_Filter(t *testing.T) {
+	var actualQuery url.Values
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualQuery = r.URL.Query()
+		w.Write([]byte(`{"data": [{"id": "4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43", "type": "payments", "attributes": {"amount": "100.21", "currency": "GBP"}}]}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	opts := &f3client.PaymentListOptions{
+		ListOptions: f3client.ListOptions{PageSize: 10},
+		Filter: f3client.PaymentFilter{
+			Currency:                      "GBP",
+			BeneficiaryPartyAccountNumber: "31926819",
+			ProcessingDateFrom:            "2021-10-01",
+			ProcessingDateTo:              "2021-10-31",
+		},
+	}
+
+	payments, _, err := client.Payments.List(context.Background(), opts)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	expected := url.Values{
+		"page[size]":       []string{"10"},
+		"filter[currency]": []string{"GBP"},
+		"filter[beneficiary_party.account_number]": []string{"31926819"},
+		"filter[processing_date_from]":             []string{"2021-10-01"},
+		"filter[processing_date_to]":               []string{"2021-10-31"},
+	}
+
+	assert.Equal(t, expected, actualQuery)
+	if assert.Len(t, payments, 1) {
+		assert.Equal(t, "100.21", payments[0].Attributes.Amount)
+	}
+}
+
+func Test_Unit_PaymentService_List_Filter_InvalidDateRange(t *testing.T) {
+	client, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	opts := &f3client.PaymentListOptions{
+		Filter: f3client.PaymentFilter{
+			ProcessingDateFrom: "2021-10-31",
+			ProcessingDateTo:   "2021-10-01",
+		},
+	}
+
+	_, _, err = client.Payments.List(context.Background(), opts)
+
+	var targetErr *f3client.ArgumentError
+	assert.ErrorAs(t, err, &targetErr)
+}
+
+func Test_Unit_PaymentService_Iterate(t *testing.T) {
+	// mock the server, two pages with one payment each
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page[number]") == "1" {
+			w.Write([]byte(`{"data": [{"id": "2b3f6a5e-1b7a-4c55-8c0d-3e8c0f6d1f1a", "type": "payments", "attributes": {"amount": "2.00"}}]}`))
+			return
+		}
+		w.Write([]byte(`{"data": [{"id": "4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43", "type": "payments", "attributes": {"amount": "1.00"}}],
+			"links": {"next": "/v1/transaction/payments?page%5Bnumber%5D=1"}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	amounts := []string{}
+	it := client.Payments.Iterate(nil)
+	for it.Next(context.Background()) {
+		amounts = append(amounts, it.Payment().Attributes.Amount)
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"1.00", "2.00"}, amounts)
+}
+
+func Test_Unit_PaymentService_Create_DuplicateResolvedToFetch(t *testing.T) {
+	// the payment was created by an earlier attempt
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error_message": "Payment cannot be created as it violates a duplicate constraint"}`))
+			return
+		}
+		w.Write([]byte(paymentResponse))
+	}))
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	paymentUUID, _ := uuid.Parse("4ee3a8d8-ca7b-4290-a52c-dd5b6165ec43")
+	organisationUUID, _ := uuid.Parse("743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb")
+
+	actual := &f3client.Payment{
+		ID:             paymentUUID,
+		OrganisationID: organisationUUID,
+		Attributes: f3client.PaymentAttributes{
+			Amount:   "100.21",
+			Currency: "GBP",
+		},
+	}
+
+	err = client.Payments.Create(context.Background(), actual)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-10-03T13:44:27.809Z", actual.CreatedOn)
+}
@@ -0,0 +1,202 @@
+package f3client
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Rate limit headers sent back by the form3 apis
+const (
+	RateLimitLimitHeader     string = "X-RateLimit-Limit"
+	RateLimitRemainingHeader string = "X-RateLimit-Remaining"
+	RateLimitResetHeader     string = "X-RateLimit-Reset"
+)
+
+// RateLimitState is a snapshot of the client side rate limiter, see Client.RateLimit
+type RateLimitState struct {
+	// Rate is the number of requests per second allowed by the client side token bucket
+	Rate float64
+	// Burst is the maximum number of requests that can be sent at once
+	Burst int
+	// Tokens is the number of requests that can be sent right away
+	Tokens float64
+
+	// Limit, Remaining and Reset are the values last sent back by the api in the rate limit
+	// headers, they are zero until the api has sent them
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// WithRateLimit configures f3client.Client to throttle the requests being sent to the apis
+//
+// The limiter is a token bucket that allows requestsPerSecond requests on average, with bursts of
+// up to burst requests. It is shared by all the services and goroutines using the client. The limiter
+// also adapts to the X-RateLimit-* headers sent back by the apis, holding back all requests until
+// the reset time once the api reports that no requests are remaining.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	f := func(c *Client) error {
+		if requestsPerSecond <= 0 {
+			return NewArgError("requestsPerSecond", "requestsPerSecond must be greater than 0")
+		}
+		if burst < 1 {
+			return NewArgError("burst", "burst must be at least 1")
+		}
+
+		c.rateLimiter = newRateLimiter(requestsPerSecond, burst)
+		return nil
+	}
+	return f
+}
+
+// RateLimit returns the current state of the client side rate limiter.
+// If the client was created without WithRateLimit the zero value is returned
+func (c *Client) RateLimit() RateLimitState {
+	if c.rateLimiter == nil {
+		return RateLimitState{}
+	}
+
+	return c.rateLimiter.state()
+}
+
+// rateLimiter is a token bucket that is also aware of the rate limit reported by the api
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  int
+	tokens float64
+	last   time.Time
+
+	// state reported by the api
+	limit     int
+	remaining int
+	reset     time.Time
+
+	now func() time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:   rate,
+		burst:  burst,
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// wait blocks until a request is allowed to be sent or the context is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		delay := l.reserve()
+		if delay <= 0 {
+			return nil
+		}
+
+		err := sleep(ctx, delay)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token if one is available and the api has not reported the limit as exhausted,
+// otherwise it returns how long to wait before trying again
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.refill(now)
+
+	if !l.reset.IsZero() {
+		if now.Before(l.reset) {
+			if l.remaining <= 0 {
+				return l.reset.Sub(now)
+			}
+		} else {
+			// the window reported by the api is over, forget about it
+			// until the api reports the next one
+			l.limit, l.remaining, l.reset = 0, 0, time.Time{}
+		}
+	}
+
+	if l.tokens < 1 {
+		return time.Duration(math.Ceil((1 - l.tokens) / l.rate * float64(time.Second)))
+	}
+
+	l.tokens--
+	if !l.reset.IsZero() {
+		l.remaining--
+	}
+
+	return 0
+}
+
+// refill adds the tokens earned since the last refill
+func (l *rateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	if elapsed > 0 {
+		l.tokens = math.Min(float64(l.burst), l.tokens+elapsed*l.rate)
+		l.last = now
+	}
+}
+
+// update records the rate limit headers sent back by the api
+func (l *rateLimiter) update(httpResp *http.Response) {
+	if httpResp == nil {
+		return
+	}
+
+	remaining, err := strconv.Atoi(httpResp.Header.Get(RateLimitRemainingHeader))
+	if err != nil {
+		return
+	}
+	reset, ok := parseRateLimitReset(httpResp.Header.Get(RateLimitResetHeader), l.now())
+	if !ok {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limit, _ = strconv.Atoi(httpResp.Header.Get(RateLimitLimitHeader))
+	l.remaining = remaining
+	l.reset = reset
+}
+
+func (l *rateLimiter) state() RateLimitState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(l.now())
+
+	return RateLimitState{
+		Rate:      l.rate,
+		Burst:     l.burst,
+		Tokens:    l.tokens,
+		Limit:     l.limit,
+		Remaining: l.remaining,
+		Reset:     l.reset,
+	}
+}
+
+// parseRateLimitReset parses the reset header, which is either a unix timestamp
+// in seconds or the number of seconds until the window resets
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		return time.Time{}, false
+	}
+
+	// anything that big can only be a unix timestamp
+	if seconds > 1000000000 {
+		return time.Unix(seconds, 0), true
+	}
+
+	return now.Add(time.Duration(seconds) * time.Second), true
+}
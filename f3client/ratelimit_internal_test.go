@@ -0,0 +1,71 @@
+package f3client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestRateLimiter returns a limiter driven by a fake clock that is moved with the returned func
+func newTestRateLimiter(rate float64, burst int) (*rateLimiter, func(time.Duration)) {
+	now := time.Date(2021, 10, 3, 13, 44, 27, 0, time.UTC)
+
+	l := newRateLimiter(rate, burst)
+	l.last = now
+	l.now = func() time.Time { return now }
+
+	return l, func(d time.Duration) { now = now.Add(d) }
+}
+
+func Test_Unit_RateLimiter_Reserve_Burst(t *testing.T) {
+	l, _ := newTestRateLimiter(10, 2)
+
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, 100*time.Millisecond, l.reserve())
+}
+
+func Test_Unit_RateLimiter_Reserve_Refill(t *testing.T) {
+	l, advance := newTestRateLimiter(10, 1)
+
+	assert.Equal(t, time.Duration(0), l.reserve())
+	advance(50 * time.Millisecond)
+	assert.Equal(t, 50*time.Millisecond, l.reserve())
+	advance(50 * time.Millisecond)
+	assert.Equal(t, time.Duration(0), l.reserve())
+}
+
+func Test_Unit_RateLimiter_Reserve_ApiExhausted(t *testing.T) {
+	l, advance := newTestRateLimiter(10, 10)
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set(RateLimitLimitHeader, "100")
+	resp.Header.Set(RateLimitRemainingHeader, "1")
+	resp.Header.Set(RateLimitResetHeader, "2")
+	l.update(resp)
+
+	// one request left in the window, then wait for the reset
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, 2*time.Second, l.reserve())
+
+	advance(2 * time.Second)
+	assert.Equal(t, time.Duration(0), l.reserve())
+	assert.Equal(t, 0, l.state().Limit)
+}
+
+func Test_Unit_ParseRateLimitReset(t *testing.T) {
+	now := time.Date(2021, 10, 3, 13, 44, 27, 0, time.UTC)
+
+	relative, ok := parseRateLimitReset("30", now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(30*time.Second), relative)
+
+	absolute, ok := parseRateLimitReset("1633268727", now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Unix(1633268727, 0), absolute)
+
+	_, ok = parseRateLimitReset("", now)
+	assert.False(t, ok)
+}
@@ -0,0 +1,117 @@
+package f3client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unit_WithRateLimit_InvalidRate(t *testing.T) {
+	_, err := f3client.NewClient(f3client.WithRateLimit(0, 1))
+
+	var targetErr *f3client.ArgumentError
+	assert.ErrorAs(t, err, &targetErr)
+}
+
+func Test_Unit_RateLimit_SharedAcrossGoroutines(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "type": "accounts", "attributes": {"country": "GB"}}}`))
+	}))
+	defer server.Close()
+
+	// 20 requests per second, one request at a time
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithRateLimit(20, 1))
+	if err != nil {
+		panic(err)
+	}
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Accounts.Fetch(context.Background(), uuid.New())
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// the first request goes through right away, the other four
+	// have to wait 50ms each for a token
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(190*time.Millisecond))
+}
+
+func Test_Unit_RateLimit_Headers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(f3client.RateLimitLimitHeader, "1000")
+		w.Header().Set(f3client.RateLimitRemainingHeader, "998")
+		w.Header().Set(f3client.RateLimitResetHeader, "60")
+		w.Write([]byte(`{"data": {"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "type": "accounts", "attributes": {"country": "GB"}}}`))
+	}))
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithRateLimit(100, 10))
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = c.Accounts.Fetch(context.Background(), uuid.New())
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	state := c.RateLimit()
+	assert.Equal(t, float64(100), state.Rate)
+	assert.Equal(t, 10, state.Burst)
+	assert.Equal(t, 1000, state.Limit)
+	assert.Equal(t, 998, state.Remaining)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), state.Reset, 2*time.Second)
+}
+
+func Test_Unit_RateLimit_ExhaustedRespectsContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(f3client.RateLimitLimitHeader, "1")
+		w.Header().Set(f3client.RateLimitRemainingHeader, "0")
+		w.Header().Set(f3client.RateLimitResetHeader, "60")
+		w.Write([]byte(`{"data": {"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "type": "accounts", "attributes": {"country": "GB"}}}`))
+	}))
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithRateLimit(100, 10))
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = c.Accounts.Fetch(context.Background(), uuid.New())
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	// the api reported no more requests until the reset, so the next
+	// request is held back until the context expires
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = c.Accounts.Fetch(ctx, uuid.New())
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func Test_Unit_RateLimit_NotConfigured(t *testing.T) {
+	c, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	assert.Equal(t, f3client.RateLimitState{}, c.RateLimit())
+}
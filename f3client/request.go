@@ -40,8 +40,12 @@ func MarshalToRequestBody(request interface{}, requestType string) (*[]byte, err
 	}
 
 	// add other generic mandatory attributes to the map[string]interface{}
+	// the version of the request object is kept, so that updates are sent
+	// with the version they were made against
 	inInteface["type"] = requestType
-	inInteface["version"] = 0
+	if _, ok := inInteface["version"]; !ok {
+		inInteface["version"] = 0
+	}
 
 	// wrap the whole thing in data
 	inReqBody["data"] = inInteface
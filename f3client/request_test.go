@@ -132,3 +132,30 @@ func Test_Unit_MarshalToRequestBody_IDMissing(t *testing.T) {
 
 	assert.EqualError(t, err, "id is mandatory in the request body")
 }
+
+func Test_Unit_MarshalToRequestBody_KeepsVersion(t *testing.T) {
+	accUUId := uuid.New()
+	orgUUId := uuid.New()
+
+	accUpdateReq := f3client.Account{
+		ID:             accUUId,
+		OrganisationID: orgUUId,
+		Version:        4,
+		Attributes: f3client.AccountAttributes{
+			Country: "GB",
+		},
+	}
+
+	actual, err := f3client.MarshalToRequestBody(accUpdateReq, "accounts")
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	body := map[string]map[string]interface{}{}
+	err = json.Unmarshal(*actual, &body)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, float64(4), body["data"]["version"])
+}
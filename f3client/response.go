@@ -12,6 +12,9 @@ import (
 type Response struct {
 	Data  ResponseData `json:"data,omitempty"`
 	Links Links        `json:"links,omitempty"`
+
+	// Attempts is the number of times the request was sent before this response was received
+	Attempts int `json:"-"`
 }
 
 type ResponseData struct {
@@ -22,6 +25,17 @@ type ResponseData struct {
 	CreatedOn      string      `json:"created_on,omitempty"`
 	ModifiedOn     string      `json:"modified_on,omitempty"`
 	Attributes     interface{} `json:"attributes,omitempty"`
+	Relationships  interface{} `json:"relationships,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (d *ResponseData) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, d)
+}
+
+func (d ResponseData) MarshalJSON() ([]byte, error) {
+	return marshalExtra(d)
 }
 
 type Links struct {
@@ -52,3 +66,34 @@ func (r *Response) ConvertTo(targetType interface{}) error {
 		return errors.New("targetType cannot be nil")
 	}
 }
+
+// ListResponse is Form3 standard response object for list apis, the data field
+// holds an array of service specific resources instead of a single resource.
+type ListResponse struct {
+	Data  []ResponseData `json:"data"`
+	Links Links          `json:"links,omitempty"`
+
+	// Attempts is the number of times the request was sent before this response was received
+	Attempts int `json:"-"`
+}
+
+// ConvertTo converts the response object's data array to the type being passed in the argument.
+// The target type is expected to be a pointer to a slice of the resource type, example *[]Account
+func (r *ListResponse) ConvertTo(targetType interface{}) error {
+	if targetType == nil {
+		return errors.New("targetType cannot be nil")
+	}
+
+	// make sure an empty page results in an empty slice rather than nil
+	data := r.Data
+	if data == nil {
+		data = []ResponseData{}
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(encoded, targetType)
+}
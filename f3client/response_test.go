@@ -124,3 +124,67 @@ func Test_Unit_ConvertTo_IncompatableTargetType(t *testing.T) {
 	assert.ErrorAs(t, actualerr, &expectederr)
 
 }
+
+func Test_Unit_ListResponse_ConvertTo(t *testing.T) {
+
+	accId := uuid.New()
+	orgId := uuid.New()
+
+	response := f3client.ListResponse{
+		Data: []f3client.ResponseData{
+			{
+				Type:           "accounts",
+				ID:             accId,
+				Version:        1,
+				OrganisationID: orgId,
+				Attributes: f3client.AccountAttributes{
+					Country: "GB",
+					Bic:     "NWBKGB22",
+				},
+			},
+		},
+	}
+
+	expected := []f3client.Account{
+		{
+			ID:             accId,
+			Version:        1,
+			OrganisationID: orgId,
+			Attributes: f3client.AccountAttributes{
+				Country: "GB",
+				Bic:     "NWBKGB22",
+			},
+		},
+	}
+
+	actual := []f3client.Account{}
+	err := response.ConvertTo(&actual)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func Test_Unit_ListResponse_ConvertTo_EmptyData(t *testing.T) {
+
+	response := f3client.ListResponse{}
+
+	actual := []f3client.Account{}
+	err := response.ConvertTo(&actual)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	assert.NotNil(t, actual)
+	assert.Empty(t, actual)
+}
+
+func Test_Unit_ListResponse_ConvertTo_NilTargetType(t *testing.T) {
+
+	response := f3client.ListResponse{}
+
+	err := response.ConvertTo(nil)
+
+	assert.EqualError(t, err, "targetType cannot be nil")
+}
@@ -0,0 +1,199 @@
+package f3client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// IdempotencyKeyHeader is the request header carrying the idempotency key of a request.
+// Requests with non idempotent methods (POST, PATCH) are only retried when this header is set.
+const IdempotencyKeyHeader string = "Idempotency-Key"
+
+// RetryPolicy configures how the client retries requests that failed
+// with a transient error, see WithRetry
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request, including the first one
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry, it doubles with every attempt
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between two attempts
+	MaxDelay time.Duration
+
+	// Jitter is the fraction (0 to 1) of the delay that is randomised, so that
+	// clients failing at the same time do not retry at the same time
+	Jitter float64
+
+	// RetryableStatusCodes are the response status codes that are retried
+	RetryableStatusCodes []int
+
+	// RespectRetryAfter makes the client wait for the duration sent back by the api
+	// in the Retry-After header, when it is longer than the computed delay
+	RespectRetryAfter bool
+}
+
+// DefaultRetryPolicy returns a retry policy that makes up to 3 attempts, retrying
+// 429, 502, 503 and 504 responses as well as connection errors
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:          3,
+		BaseDelay:            100 * time.Millisecond,
+		MaxDelay:             5 * time.Second,
+		Jitter:               0.5,
+		RetryableStatusCodes: []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		RespectRetryAfter:    true,
+	}
+}
+
+// WithRetry configures f3client.Client to retry requests failing with a transient
+// error as per the policy being passed
+//
+// POST and PATCH requests are only retried when they carry an idempotency key.
+func WithRetry(policy RetryPolicy) Option {
+	f := func(c *Client) error {
+		if policy.MaxAttempts < 1 {
+			return NewArgError("MaxAttempts", "MaxAttempts must be at least 1")
+		}
+		if policy.Jitter < 0 || policy.Jitter > 1 {
+			return NewArgError("Jitter", "Jitter must be between 0 and 1")
+		}
+		if policy.BaseDelay < 0 || policy.MaxDelay < 0 {
+			return NewArgError("BaseDelay", "delays cannot be negative")
+		}
+
+		c.retryPolicy = &policy
+		return nil
+	}
+	return f
+}
+
+// RetryError is returned when a request failed with a transport error
+// after being attempted more than once
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (re *RetryError) Error() string {
+	return fmt.Sprintf("request failed after %d attempts : %s", re.Attempts, re.Err.Error())
+}
+
+func (re *RetryError) Unwrap() error {
+	return re.Err
+}
+
+// maxAttempts returns the number of attempts allowed for the request
+func (p *RetryPolicy) maxAttempts(request *http.Request) int {
+	if p == nil {
+		return 1
+	}
+
+	// non idempotent requests can only be repeated safely with an idempotency key
+	if (request.Method == http.MethodPost || request.Method == http.MethodPatch) &&
+		request.Header.Get(IdempotencyKeyHeader) == "" {
+		return 1
+	}
+
+	if !canRewind(request) {
+		return 1
+	}
+
+	return p.MaxAttempts
+}
+
+// canRewind reports whether the request can be sent again, a body
+// that cannot be rewound cannot be sent again
+func canRewind(request *http.Request) bool {
+	return request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+}
+
+// shouldRetry decides if the outcome of an attempt is worth retrying
+func (p *RetryPolicy) shouldRetry(ctx context.Context, httpResp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	for _, code := range p.RetryableStatusCodes {
+		if httpResp.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// delay computes how long to wait before the next attempt
+func (p *RetryPolicy) delay(attempt int, httpResp *http.Response) time.Duration {
+	backoff := float64(p.BaseDelay) * math.Pow(2, float64(attempt-1))
+	if p.MaxDelay > 0 && backoff > float64(p.MaxDelay) {
+		backoff = float64(p.MaxDelay)
+	}
+
+	// randomise the configured fraction of the delay
+	backoff = backoff - backoff*p.Jitter*rand.Float64()
+	delay := time.Duration(backoff)
+
+	if p.RespectRetryAfter && httpResp != nil {
+		if retryAfter, ok := parseRetryAfter(httpResp.Header.Get("Retry-After")); ok && retryAfter > delay {
+			delay = retryAfter
+		}
+	}
+
+	return delay
+}
+
+// parseRetryAfter parses the Retry-After header which is either
+// a number of seconds or an http date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date), true
+	}
+
+	return 0, false
+}
+
+// rewind prepares the request for another attempt, giving it a fresh copy of the body
+func rewind(ctx context.Context, request *http.Request) (*http.Request, error) {
+	retryReq := request.Clone(ctx)
+
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retryReq.Body = body
+	}
+
+	return retryReq, nil
+}
+
+// sleep waits for the delay to pass, returning early with an error if the context is done
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
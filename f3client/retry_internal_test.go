@@ -0,0 +1,64 @@
+package f3client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unit_RetryPolicy_Delay_Backoff(t *testing.T) {
+	policy := RetryPolicy{
+		BaseDelay: 100 * time.Millisecond,
+		MaxDelay:  time.Second,
+	}
+
+	assert.Equal(t, 100*time.Millisecond, policy.delay(1, nil))
+	assert.Equal(t, 200*time.Millisecond, policy.delay(2, nil))
+	assert.Equal(t, 400*time.Millisecond, policy.delay(3, nil))
+	assert.Equal(t, time.Second, policy.delay(5, nil))
+}
+
+func Test_Unit_RetryPolicy_Delay_Jitter(t *testing.T) {
+	policy := RetryPolicy{
+		BaseDelay: 100 * time.Millisecond,
+		MaxDelay:  time.Second,
+		Jitter:    0.5,
+	}
+
+	for i := 0; i < 100; i++ {
+		delay := policy.delay(1, nil)
+		assert.GreaterOrEqual(t, int64(delay), int64(50*time.Millisecond))
+		assert.LessOrEqual(t, int64(delay), int64(100*time.Millisecond))
+	}
+}
+
+func Test_Unit_RetryPolicy_Delay_RetryAfter(t *testing.T) {
+	policy := RetryPolicy{
+		BaseDelay:         100 * time.Millisecond,
+		MaxDelay:          time.Second,
+		RespectRetryAfter: true,
+	}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "3")
+
+	assert.Equal(t, 3*time.Second, policy.delay(1, resp))
+
+	policy.RespectRetryAfter = false
+	assert.Equal(t, 100*time.Millisecond, policy.delay(1, resp))
+}
+
+func Test_Unit_ParseRetryAfter(t *testing.T) {
+	seconds, ok := parseRetryAfter("120")
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, seconds)
+
+	date, ok := parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.InDelta(t, float64(time.Hour), float64(date), float64(2*time.Second))
+
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+}
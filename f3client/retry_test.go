@@ -0,0 +1,272 @@
+package f3client_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// testRetryPolicy retries quickly so that the tests do not have to wait
+func testRetryPolicy() f3client.RetryPolicy {
+	policy := f3client.DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 5 * time.Millisecond
+	return policy
+}
+
+func Test_Unit_WithRetry_InvalidPolicy(t *testing.T) {
+	policy := testRetryPolicy()
+	policy.MaxAttempts = 0
+
+	_, err := f3client.NewClient(f3client.WithRetry(policy))
+
+	var targetErr *f3client.ArgumentError
+	assert.ErrorAs(t, err, &targetErr)
+}
+
+func Test_Unit_Retry_ServiceUnavailable(t *testing.T) {
+	var calls int32
+
+	// fail twice before succeeding
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"data": {"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "type": "accounts", "attributes": {"country": "GB"}}}`))
+	}))
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithRetry(testRetryPolicy()))
+	if err != nil {
+		panic(err)
+	}
+
+	req, err := c.NewRequest(context.Background(), f3client.Get, "/v1/organisation/accounts/bc8fb900-d6fd-41d0-b187-dc23ba928712", c.Accounts.ObjectType, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	resp, err := c.SendRequest(context.Background(), req)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, 3, resp.Attempts)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func Test_Unit_Retry_GivesUp(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithRetry(testRetryPolicy()))
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = c.Accounts.Fetch(context.Background(), uuid.New())
+
+	var apiErr *f3client.APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusGatewayTimeout, apiErr.StatusCode)
+		assert.Equal(t, 3, apiErr.Attempts)
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func Test_Unit_Retry_NotRetryableStatus(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithRetry(testRetryPolicy()))
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = c.Accounts.Fetch(context.Background(), uuid.New())
+
+	assert.True(t, f3client.IsServerError(err))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func Test_Unit_Retry_PostWithoutIdempotencyKey(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithRetry(testRetryPolicy()))
+	if err != nil {
+		panic(err)
+	}
+
+	account := &f3client.Account{
+		ID:             uuid.New(),
+		OrganisationID: uuid.New(),
+		Attributes: f3client.AccountAttributes{
+			Country: "GB",
+			Name:    []string{"Jon Doe"},
+		},
+	}
+
+	req, err := c.NewRequest(context.Background(), f3client.Post, "/v1/organisation/accounts", c.Accounts.ObjectType, account)
+	if err != nil {
+		panic(err)
+	}
+	// the client generates a key when retries are enabled, drop it
+	req.Header.Del(f3client.IdempotencyKeyHeader)
+
+	_, err = c.SendRequest(context.Background(), req)
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func Test_Unit_Retry_PostWithIdempotencyKey_RewindsBody(t *testing.T) {
+	var calls int32
+	bodies := make(chan string, 3)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies <- string(body)
+		if atomic.AddInt32(&calls, 1) < 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "type": "accounts", "attributes": {"country": "GB"}}}`))
+	}))
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithRetry(testRetryPolicy()))
+	if err != nil {
+		panic(err)
+	}
+
+	account := &f3client.Account{
+		ID:             uuid.New(),
+		OrganisationID: uuid.New(),
+		Attributes: f3client.AccountAttributes{
+			Country: "GB",
+			Name:    []string{"Jon Doe"},
+		},
+	}
+
+	req, err := c.NewRequest(context.Background(), f3client.Post, "/v1/organisation/accounts", c.Accounts.ObjectType, account)
+	if err != nil {
+		panic(err)
+	}
+	req.Header.Set(f3client.IdempotencyKeyHeader, uuid.New().String())
+
+	resp, err := c.SendRequest(context.Background(), req)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	first, second := <-bodies, <-bodies
+	assert.Equal(t, 2, resp.Attempts)
+	assert.NotEmpty(t, first)
+	assert.Equal(t, first, second)
+}
+
+func Test_Unit_Retry_ConnectionError(t *testing.T) {
+	var calls int32
+
+	// drop the connection without sending any response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			panic(err)
+		}
+		conn.Close()
+	}))
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithRetry(testRetryPolicy()))
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = c.Accounts.Fetch(context.Background(), uuid.New())
+
+	var retryErr *f3client.RetryError
+	if assert.ErrorAs(t, err, &retryErr) {
+		assert.Equal(t, 3, retryErr.Attempts)
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func Test_Unit_Retry_ContextCancelledBetweenAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithRetry(testRetryPolicy()))
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = c.Accounts.Fetch(ctx, uuid.New())
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, int64(time.Since(start)), int64(5*time.Second))
+}
+
+func Test_Unit_Retry_ContextCancelledDuringBackoff_KeepsRetryError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	policy := testRetryPolicy()
+	policy.BaseDelay = 10 * time.Second
+	policy.MaxDelay = 10 * time.Second
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithRetry(policy))
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = c.Accounts.Fetch(ctx, uuid.New())
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	var retryErr *f3client.RetryError
+	if assert.True(t, errors.As(err, &retryErr)) {
+		assert.Equal(t, 1, retryErr.Attempts)
+	}
+	assert.True(t, f3client.IsServerError(err))
+}
@@ -0,0 +1,207 @@
+package f3client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type RoleService struct {
+	service
+	ObjectType string
+}
+
+// AceAction is the action an access control entry allows on a record type
+type AceAction string
+
+// Actions that can be allowed by an access control entry
+const (
+	AceActionCreate        AceAction = "CREATE"
+	AceActionRead          AceAction = "READ"
+	AceActionEdit          AceAction = "EDIT"
+	AceActionDelete        AceAction = "DELETE"
+	AceActionCreateApprove AceAction = "CREATE_APPROVE"
+	AceActionEditApprove   AceAction = "EDIT_APPROVE"
+	AceActionDeleteApprove AceAction = "DELETE_APPROVE"
+)
+
+// IsValid reports whether the action is one of the known ace actions
+func (a AceAction) IsValid() bool {
+	switch a {
+	case AceActionCreate, AceActionRead, AceActionEdit, AceActionDelete,
+		AceActionCreateApprove, AceActionEditApprove, AceActionDeleteApprove:
+		return true
+	}
+	return false
+}
+
+// Role represents a role in the form3 security section, the permissions of the
+// role are given by its access control entries (aces).
+//
+// See the Roles section of https://api-docs.form3.tech/api.html for
+// more information about fields.
+type Role struct {
+	ID             uuid.UUID      `json:"id,omitempty"`
+	Version        int            `json:"version,omitempty"`
+	OrganisationID uuid.UUID      `json:"organisation_id,omitempty"`
+	CreatedOn      string         `json:"created_on,omitempty"`
+	ModifiedOn     string         `json:"modified_on,omitempty"`
+	Attributes     RoleAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (r *Role) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, r, "type")
+}
+
+func (r Role) MarshalJSON() ([]byte, error) {
+	return marshalExtra(r)
+}
+
+type RoleAttributes struct {
+	Name         string     `json:"name,omitempty"`
+	ParentRoleID *uuid.UUID `json:"parent_role_id,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ra *RoleAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ra)
+}
+
+func (ra RoleAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ra)
+}
+
+// Ace represents an access control entry, i.e. the permission given to a role
+// to perform the action on the records of the record type.
+//
+// See the ACEs section of https://api-docs.form3.tech/api.html for
+// more information about fields.
+type Ace struct {
+	ID             uuid.UUID     `json:"id,omitempty"`
+	Version        int           `json:"version,omitempty"`
+	OrganisationID uuid.UUID     `json:"organisation_id,omitempty"`
+	CreatedOn      string        `json:"created_on,omitempty"`
+	ModifiedOn     string        `json:"modified_on,omitempty"`
+	Attributes     AceAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (a *Ace) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, a, "type")
+}
+
+func (a Ace) MarshalJSON() ([]byte, error) {
+	return marshalExtra(a)
+}
+
+type AceAttributes struct {
+	RoleID     uuid.UUID `json:"role_id,omitempty"`
+	Action     AceAction `json:"action,omitempty"`
+	RecordType string    `json:"record_type,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (aa *AceAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, aa)
+}
+
+func (aa AceAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(aa)
+}
+
+// rolePath returns the path of a role, the aces of the role live under it
+func rolePath(roleId uuid.UUID) string {
+	return "/v1/security/roles/" + roleId.String()
+}
+
+// Create creates a role using form3 role api, the role is updated in place
+// with the role sent back by the api
+func (rs *RoleService) Create(ctx context.Context, role *Role) error {
+	// validate for mandatory role fields before creating new request
+	if role.Attributes.Name == "" {
+		return NewArgError("name", "name is mandatory for role create request")
+	}
+
+	return rs.create(ctx, "/v1/security/roles", rs.ObjectType, role.ID, role)
+}
+
+// Fetch gets the role with the id, the access control entries granted by the role are listed
+// with ListAces
+func (rs *RoleService) Fetch(ctx context.Context, roleId uuid.UUID) (*Role, error) {
+	role := new(Role)
+
+	err := rs.fetch(ctx, rolePath(roleId), rs.ObjectType, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// List gets a single page of form3 role objects, when opts is nil the first
+// page with the api default page size is returned
+func (rs *RoleService) List(ctx context.Context, opts *ListOptions) ([]Role, Links, error) {
+	roles := []Role{}
+
+	links, err := rs.list(ctx, addQuery("/v1/security/roles", opts.values()), rs.ObjectType, &roles)
+	if err != nil {
+		return nil, Links{}, err
+	}
+
+	return roles, links, nil
+}
+
+// CreateAce allows the role to perform the action on the record type, the ace is updated in place
+// with the ace sent back by the api
+func (rs *RoleService) CreateAce(ctx context.Context, roleId uuid.UUID, ace *Ace) error {
+	// validate for mandatory ace fields before creating new request
+	if !ace.Attributes.Action.IsValid() {
+		return NewArgError("action", "action must be one of the ace actions")
+	} else if ace.Attributes.RecordType == "" {
+		return NewArgError("record_type", "record_type is mandatory for ace create request")
+	}
+
+	if ace.Attributes.RoleID == uuid.Nil {
+		ace.Attributes.RoleID = roleId
+	} else if ace.Attributes.RoleID != roleId {
+		return NewArgError("role_id", fmt.Sprintf("role_id does not match the role %s", roleId.String()))
+	}
+
+	return rs.create(ctx, rolePath(roleId)+"/aces", "aces", ace.ID, ace)
+}
+
+// FetchAce gets an ace of the role
+func (rs *RoleService) FetchAce(ctx context.Context, roleId, aceId uuid.UUID) (*Ace, error) {
+	ace := new(Ace)
+
+	err := rs.fetch(ctx, rolePath(roleId)+"/aces/"+aceId.String(), "aces", ace)
+	if err != nil {
+		return nil, err
+	}
+
+	return ace, nil
+}
+
+// ListAces gets a single page of the aces of the role, when opts is nil the first
+// page with the api default page size is returned
+func (rs *RoleService) ListAces(ctx context.Context, roleId uuid.UUID, opts *ListOptions) ([]Ace, Links, error) {
+	aces := []Ace{}
+
+	links, err := rs.list(ctx, addQuery(rolePath(roleId)+"/aces", opts.values()), "aces", &aces)
+	if err != nil {
+		return nil, Links{}, err
+	}
+
+	return aces, links, nil
+}
+
+// DeleteAce revokes the permission given by the ace from the role
+func (rs *RoleService) DeleteAce(ctx context.Context, roleId, aceId uuid.UUID) error {
+	return rs.delete(ctx, rolePath(roleId)+"/aces/"+aceId.String(), "aces")
+}
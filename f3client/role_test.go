@@ -0,0 +1,174 @@
+package f3client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unit_RoleService_Create(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "e2f3a4b5-c6d7-4e8f-9a0b-1c2d3e4f5a6b", "type": "roles", "attributes": {"name": "payments-operator"}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	role := &f3client.Role{
+		ID:             adminRoleID,
+		OrganisationID: uuid.New(),
+		Attributes:     f3client.RoleAttributes{Name: "payments-operator"},
+	}
+
+	err = client.Roles.Create(context.Background(), role)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/security/roles", actualPath)
+	assert.Equal(t, "roles", actualBody["data"]["type"])
+	assert.Nil(t, role.Attributes.ParentRoleID)
+}
+
+func Test_Unit_RoleService_List(t *testing.T) {
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": [{"id": "e2f3a4b5-c6d7-4e8f-9a0b-1c2d3e4f5a6b", "type": "roles", "attributes": {"name": "admin"}},
+			{"id": "f3a4b5c6-d7e8-4f9a-8b1c-2d3e4f5a6b7c", "type": "roles", "attributes": {"name": "read-only", "parent_role_id": "e2f3a4b5-c6d7-4e8f-9a0b-1c2d3e4f5a6b"}}]}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	roles, _, err := client.Roles.List(context.Background(), nil)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	if assert.Len(t, roles, 2) && assert.NotNil(t, roles[1].Attributes.ParentRoleID) {
+		assert.Equal(t, adminRoleID, *roles[1].Attributes.ParentRoleID)
+	}
+}
+
+func Test_Unit_RoleService_CreateAce(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "b5c6d7e8-f9a0-4b1c-8d2e-3f4a5b6c7d8e", "type": "aces",
+			"attributes": {"role_id": "e2f3a4b5-c6d7-4e8f-9a0b-1c2d3e4f5a6b", "action": "CREATE", "record_type": "payments"}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	ace := &f3client.Ace{
+		ID:             uuid.New(),
+		OrganisationID: uuid.New(),
+		Attributes:     f3client.AceAttributes{Action: f3client.AceActionCreate, RecordType: "payments"},
+	}
+
+	err = client.Roles.CreateAce(context.Background(), adminRoleID, ace)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	attributes := actualBody["data"]["attributes"].(map[string]interface{})
+	assert.Equal(t, "/v1/security/roles/e2f3a4b5-c6d7-4e8f-9a0b-1c2d3e4f5a6b/aces", actualPath)
+	assert.Equal(t, "aces", actualBody["data"]["type"])
+	assert.Equal(t, adminRoleID.String(), attributes["role_id"])
+	assert.Equal(t, "CREATE", attributes["action"])
+}
+
+func Test_Unit_RoleService_CreateAce_Invalid(t *testing.T) {
+	client, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	cases := map[string]f3client.AceAttributes{
+		"unknown action": {Action: "UPDATE", RecordType: "payments"},
+		"no record type": {Action: f3client.AceActionRead},
+		"other role":     {Action: f3client.AceActionRead, RecordType: "payments", RoleID: readRoleID},
+	}
+
+	for name, attributes := range cases {
+		ace := &f3client.Ace{ID: uuid.New(), OrganisationID: uuid.New(), Attributes: attributes}
+
+		err = client.Roles.CreateAce(context.Background(), adminRoleID, ace)
+
+		var targetErr *f3client.ArgumentError
+		assert.ErrorAs(t, err, &targetErr, name)
+	}
+}
+
+func Test_Unit_RoleService_ListAndDeleteAces(t *testing.T) {
+	var actualMethod, actualPath string
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualMethod = r.Method
+		actualPath = r.URL.Path
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Write([]byte(`{"data": [{"id": "b5c6d7e8-f9a0-4b1c-8d2e-3f4a5b6c7d8e", "type": "aces", "attributes": {"action": "READ", "record_type": "accounts"}}]}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	aces, _, err := client.Roles.ListAces(context.Background(), adminRoleID, nil)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, "/v1/security/roles/e2f3a4b5-c6d7-4e8f-9a0b-1c2d3e4f5a6b/aces", actualPath)
+
+	if assert.Len(t, aces, 1) {
+		assert.Equal(t, f3client.AceActionRead, aces[0].Attributes.Action)
+
+		err = client.Roles.DeleteAce(context.Background(), adminRoleID, aces[0].ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodDelete, actualMethod)
+		assert.Equal(t, "/v1/security/roles/e2f3a4b5-c6d7-4e8f-9a0b-1c2d3e4f5a6b/aces/b5c6d7e8-f9a0-4b1c-8d2e-3f4a5b6c7d8e", actualPath)
+	}
+}
@@ -0,0 +1,213 @@
+package f3client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The helpers below implement the request/response round trip shared by the services,
+// every resource is sent through NewRequest and SendRequest and decoded with ConvertTo.
+
+// create posts the resource to the path and updates it in place with the resource sent back by the api
+//
+// Like AccountService.Create, a conflict caused by an identical resource already existing under
+// path/id, e.g. created by an earlier attempt, is resolved by fetching the existing resource.
+func (s *service) create(ctx context.Context, path, objectType string, id uuid.UUID, resource interface{}) error {
+	req, err := s.client.NewRequest(ctx, Post, path, objectType, resource)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.SendRequest(ctx, req)
+	if IsConflict(err) && !IsVersionMismatch(err) {
+		existing := reflect.New(reflect.TypeOf(resource).Elem())
+		fetchErr := s.fetch(ctx, path+"/"+id.String(), objectType, existing.Interface())
+		if fetchErr == nil && isDuplicate(resource, existing.Interface()) {
+			reflect.ValueOf(resource).Elem().Set(existing.Elem())
+			return nil
+		}
+		return err
+	} else if err != nil {
+		return err
+	} else if resp == nil {
+		return nil
+	}
+
+	return resp.ConvertTo(resource)
+}
+
+// fetch gets the resource at the path and decodes it into resource
+func (s *service) fetch(ctx context.Context, path, objectType string, resource interface{}) error {
+	req, err := s.client.NewRequest(ctx, Get, path, objectType, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.SendRequest(ctx, req)
+	if err != nil {
+		return err
+	} else if resp == nil {
+		return errors.New("api returned no content for " + path)
+	}
+
+	return resp.ConvertTo(resource)
+}
+
+// list gets a page of resources at the path and decodes them into resources,
+// which has to be a pointer to a slice
+func (s *service) list(ctx context.Context, path, objectType string, resources interface{}) (Links, error) {
+	req, err := s.client.NewRequest(ctx, Get, path, objectType, nil)
+	if err != nil {
+		return Links{}, err
+	}
+
+	resp, err := s.client.SendListRequest(ctx, req)
+	if err != nil {
+		return Links{}, err
+	} else if resp == nil {
+		resp = &ListResponse{}
+	}
+
+	err = resp.ConvertTo(resources)
+	if err != nil {
+		return Links{}, err
+	}
+
+	return resp.Links, nil
+}
+
+// update patches the resource at the path and updates it in place with the resource sent back by the api
+//
+// A conflict caused by a version mismatch is reported as *VersionConflictError for the id and
+// version of the resource, any other conflict is returned as the *APIError.
+func (s *service) update(ctx context.Context, path, objectType string, id uuid.UUID, version int, resource interface{}) error {
+	req, err := s.client.NewRequest(ctx, Patch, path, objectType, resource)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.SendRequest(ctx, req)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && IsVersionMismatch(apiErr) {
+			return &VersionConflictError{ID: id, Version: version, Err: apiErr}
+		}
+		return err
+	} else if resp == nil {
+		return nil
+	}
+
+	return resp.ConvertTo(resource)
+}
+
+// delete deletes the resource at the path, the path has to carry the version of the resource
+func (s *service) delete(ctx context.Context, path, objectType string) error {
+	req, err := s.client.NewRequest(ctx, Delete, path, objectType, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.SendRequest(ctx, req)
+	return err
+}
+
+// resourceIterator walks through the resources of a list api one resource at a time, the typed
+// iterators of the services decode the current resource into their own resource type
+type resourceIterator struct {
+	pager   *pager
+	page    []ResponseData
+	current ResponseData
+	err     error
+}
+
+func newResourceIterator(c *Client, objectType, path string, err error) resourceIterator {
+	return resourceIterator{
+		pager: newPager(c, objectType, path),
+		err:   err,
+	}
+}
+
+// next advances to the next resource, fetching the next page when the current one is exhausted
+func (it *resourceIterator) next(ctx context.Context) bool {
+	for len(it.page) == 0 {
+		if it.err != nil || it.pager.done {
+			return false
+		}
+
+		resp, err := it.pager.nextPage(ctx)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page = resp.Data
+	}
+
+	it.current = it.page[0]
+	it.page = it.page[1:]
+
+	return true
+}
+
+// decode converts the current resource to the type being passed
+func (it *resourceIterator) decode(resource interface{}) bool {
+	resp := Response{Data: it.current}
+
+	err := resp.ConvertTo(resource)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	return true
+}
+
+// PollOptions controls how often a resource is fetched while waiting for it to change,
+// the interval doubles after every fetch until it reaches MaxInterval. An Interval that is not
+// positive falls back to the interval of DefaultPollOptions.
+type PollOptions struct {
+	Interval    time.Duration
+	MaxInterval time.Duration
+}
+
+// DefaultPollOptions returns the poll options used when none are passed, polling
+// starts after half a second and slows down to once every 10 seconds
+func DefaultPollOptions() PollOptions {
+	return PollOptions{
+		Interval:    500 * time.Millisecond,
+		MaxInterval: 10 * time.Second,
+	}
+}
+
+// poll calls check until it reports that it is done, fails or the context is done, an interval
+// that is not positive falls back to the default interval so that the api is not flooded
+func poll(ctx context.Context, opts *PollOptions, check func() (bool, error)) error {
+	defaults := DefaultPollOptions()
+	if opts == nil {
+		opts = &defaults
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaults.Interval
+	}
+	for {
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+
+		err = sleep(ctx, interval)
+		if err != nil {
+			return err
+		}
+
+		interval *= 2
+		if opts.MaxInterval > 0 && interval > opts.MaxInterval {
+			interval = opts.MaxInterval
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package f3client
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/benjaminmishra/form3-client-go/v1/f3client/internal/httpsig"
+)
+
+// Signature algorithms supported by HTTPSigner
+const (
+	AlgorithmRSASHA256   string = "rsa-sha256"
+	AlgorithmECDSASHA256 string = "ecdsa-sha256"
+)
+
+// Signer authenticates the requests sent to the apis, see WithSigner
+//
+// Sign is called right before every attempt of a request is sent, body holds
+// the request body and is empty for requests without body.
+type Signer interface {
+	Sign(request *http.Request, body []byte) error
+}
+
+// WithSigner configures f3client.Client to sign every request with the signer being passed
+//
+// It cannot be combined with WithOAuth2, both authenticate requests through the Authorization header.
+func WithSigner(signer Signer) Option {
+	f := func(c *Client) error {
+		if signer == nil {
+			return NewArgError("signer", "signer cannot be nil")
+		}
+		c.signer = signer
+		return nil
+	}
+	return f
+}
+
+// HTTPSigner signs requests as per the form3 http message signature scheme
+//
+// Every request gets a Date header, requests with a body also get a Digest header holding the
+// SHA-256 of the body. The signature covers (request-target), host, date and accept and for requests
+// with a body also digest, content-type and content-length. It is sent in the Authorization header.
+//
+// See the authentication section of https://api-docs.form3.tech/api.html for the details of the scheme.
+type HTTPSigner struct {
+	keyID     string
+	key       crypto.Signer
+	algorithm string
+	now       func() time.Time
+}
+
+// NewHTTPSigner creates an HTTPSigner for the key id issued by form3 and the matching private key
+//
+// The private key must be PEM encoded, RSA (PKCS #1 or PKCS #8) and ECDSA (SEC 1 or PKCS #8)
+// keys are supported. An ArgumentError is returned if the key cannot be used.
+func NewHTTPSigner(keyID string, privateKeyPEM []byte) (*HTTPSigner, error) {
+	if keyID == "" {
+		return nil, NewArgError("keyID", "keyID cannot be empty")
+	}
+
+	key, err := parsePrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &HTTPSigner{
+		keyID: keyID,
+		key:   key,
+		now:   time.Now,
+	}
+
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		s.algorithm = AlgorithmRSASHA256
+	case *ecdsa.PrivateKey:
+		s.algorithm = AlgorithmECDSASHA256
+	}
+
+	return s, nil
+}
+
+// parsePrivateKey decodes an RSA or ECDSA private key from PEM
+func parsePrivateKey(privateKeyPEM []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, NewArgError("privateKeyPEM", "no PEM encoded key found")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		switch k := key.(type) {
+		case *rsa.PrivateKey:
+			return k, nil
+		case *ecdsa.PrivateKey:
+			return k, nil
+		}
+	}
+
+	return nil, NewArgError("privateKeyPEM", "only RSA and ECDSA private keys are supported")
+}
+
+// Algorithm returns the signature algorithm matching the private key of the signer
+func (s *HTTPSigner) Algorithm() string {
+	return s.algorithm
+}
+
+// Sign adds the Date, Digest and Authorization headers to the request
+func (s *HTTPSigner) Sign(request *http.Request, body []byte) error {
+	request.Header.Set("Date", s.now().UTC().Format(http.TimeFormat))
+
+	headers := []string{"(request-target)", "host", "date", "accept"}
+
+	if len(body) > 0 {
+		digest := sha256.Sum256(body)
+		request.Header.Set("Digest", "SHA-256="+base64.StdEncoding.EncodeToString(digest[:]))
+		request.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		if request.Header.Get("Content-Type") == "" {
+			request.Header.Set("Content-Type", Accepts)
+		}
+
+		headers = append(headers, "digest", "content-type", "content-length")
+	}
+
+	signature, err := s.signature(httpsig.SigningString(request, headers))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Authorization", fmt.Sprintf(`Signature keyId="%s",algorithm="%s",headers="%s",signature="%s"`,
+		s.keyID, s.algorithm, strings.Join(headers, " "), signature))
+
+	return nil
+}
+
+// signature signs the SHA-256 of the signing string and returns it base64 encoded
+func (s *HTTPSigner) signature(signingString string) (string, error) {
+	hashed := sha256.Sum256([]byte(signingString))
+
+	var signature []byte
+	var err error
+
+	switch key := s.key.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+	default:
+		signature, err = s.key.Sign(rand.Reader, hashed[:], crypto.SHA256)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// requestBody returns the body of the request without consuming it
+func requestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return ioutil.ReadAll(body)
+	}
+
+	// the body can only be read once, put back a copy of it
+	body, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	request.Body.Close()
+	request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
@@ -0,0 +1,79 @@
+package f3client
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/benjaminmishra/form3-client-go/v1/f3client/internal/httpsig"
+	"github.com/stretchr/testify/assert"
+)
+
+// golden Authorization headers produced by signing with testdata/rsa_private_key.pem,
+// rsa pkcs #1 v1.5 signatures are deterministic so these never change
+const (
+	goldenGetAuthorization  = `Signature keyId="75a8ba12-fff2-4a52-ad8a-e8b34c5ccec8",algorithm="rsa-sha256",headers="(request-target) host date accept",signature="pA1xCTN1wLhX1XkZHSEmiEqeZQAVdmYxdrHv0JIPDZJwDL6dcpBSYSAsTxRSahbr09YgdOWLNJgB0WKdsHUYxXS+D+0GAeQbTasaABNz2Izfa9FqEgOyt++dodJp7+RLNtF2ExLw/x1tzt3hC/tw0I2yTGuAvjS9qdkPLxbrOx4A0hRRwI2DaiNGYstzZqxRuGNypHlOE1aYvEkNFkLD55K0aAJmhrZXy9a19JgHO2ZQTLBkZA7qISBQdp9MmGA5D039eJsIoUHq33L1qApVr9sUFr1dhrLjbk+IGQ1mprKRXALtr+Bk1wZpVIYlMrsDVCw0EhMEkgnFPxiD2hx8zA=="`
+	goldenPostAuthorization = `Signature keyId="75a8ba12-fff2-4a52-ad8a-e8b34c5ccec8",algorithm="rsa-sha256",headers="(request-target) host date accept digest content-type content-length",signature="d1iU2zcbQqbDWMG+Q7ujVW1MnrCExnxrP91/xxCfTbnW0W2Bx2iL7YZQwFWvwPFnQV4Lpf0KcYHygBtEXGuonVV5FZ2lZP87sjYbYlznjg5cfjB7JWKTsVAJwOdoLq4tLZpwxH24yHWFusPUmBK21/b4Iwb+MvYzZV3hEeoZQLFmyu37TvoFuBOfGec2zmavgFIo2BQj61J8bb5Jy+i49H3MrboH8EUsTKhv1dAb/afG8rJv+3wgNZxS/urrKgKTTeLJFv+DghBbn2L++vdDfNlPrPnXTznrqhYx6gPt49K0BHeuaA6WXTaBO6YCGT+/7uCmHy9EUKt0def/OmoUCw=="`
+)
+
+// newGoldenSigner returns an rsa signer with a fixed clock, so that
+// the signatures it produces are always the same
+func newGoldenSigner() *HTTPSigner {
+	pemBytes, err := ioutil.ReadFile("testdata/rsa_private_key.pem")
+	if err != nil {
+		panic(err)
+	}
+
+	signer, err := NewHTTPSigner("75a8ba12-fff2-4a52-ad8a-e8b34c5ccec8", pemBytes)
+	if err != nil {
+		panic(err)
+	}
+	signer.now = func() time.Time { return time.Date(2021, 10, 3, 13, 44, 27, 0, time.UTC) }
+
+	return signer
+}
+
+func Test_Unit_HTTPSigner_GoldenSignature_Get(t *testing.T) {
+	req, err := http.NewRequest(Get, "https://api.form3.tech/v1/organisation/accounts/bc8fb900-d6fd-41d0-b187-dc23ba928712", nil)
+	if err != nil {
+		panic(err)
+	}
+	req.Header.Set("Accept", Accepts)
+
+	err = newGoldenSigner().Sign(req, nil)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	expectedSigningString := "(request-target): get /v1/organisation/accounts/bc8fb900-d6fd-41d0-b187-dc23ba928712\n" +
+		"host: api.form3.tech\n" +
+		"date: Sun, 03 Oct 2021 13:44:27 GMT\n" +
+		"accept: application/vnd.api+json"
+
+	assert.Equal(t, expectedSigningString, httpsig.SigningString(req, []string{"(request-target)", "host", "date", "accept"}))
+	assert.Equal(t, "Sun, 03 Oct 2021 13:44:27 GMT", req.Header.Get("Date"))
+	assert.Empty(t, req.Header.Get("Digest"))
+	assert.Equal(t, goldenGetAuthorization, req.Header.Get("Authorization"))
+}
+
+func Test_Unit_HTTPSigner_GoldenSignature_Post(t *testing.T) {
+	body := []byte(`{"data":{"id":"bc8fb900-d6fd-41d0-b187-dc23ba928712","type":"accounts"}}`)
+
+	req, err := http.NewRequest(Post, "https://api.form3.tech/v1/organisation/accounts", bytes.NewReader(body))
+	if err != nil {
+		panic(err)
+	}
+	req.Header.Set("Accept", Accepts)
+
+	err = newGoldenSigner().Sign(req, body)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "SHA-256=MAlDqlqDUl+qo785ELgZf7GzCiq+5vng3MfoL1Pqf6c=", req.Header.Get("Digest"))
+	assert.Equal(t, Accepts, req.Header.Get("Content-Type"))
+	assert.Equal(t, "72", req.Header.Get("Content-Length"))
+	assert.Equal(t, goldenPostAuthorization, req.Header.Get("Authorization"))
+}
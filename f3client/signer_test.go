@@ -0,0 +1,242 @@
+package f3client_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// readTestFile reads a file from the testdata folder
+func readTestFile(name string) []byte {
+	content, err := ioutil.ReadFile("testdata/" + name)
+	if err != nil {
+		panic(err)
+	}
+	return content
+}
+
+// readPublicKey reads a PEM encoded public key from the testdata folder
+func readPublicKey(name string) crypto.PublicKey {
+	block, _ := pem.Decode(readTestFile(name))
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// verifySignature is a minimal verifier of the form3 http message signature, it rebuilds
+// the signing string from the received request and checks it against the public key
+func verifySignature(r *http.Request, publicKey crypto.PublicKey) error {
+	params := map[string]string{}
+	authorization := strings.TrimPrefix(r.Header.Get("Authorization"), "Signature ")
+	for _, param := range strings.Split(authorization, ",") {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("malformed authorization header %q", authorization)
+		}
+		params[kv[0]] = strings.Trim(kv[1], `"`)
+	}
+
+	lines := []string{}
+	for _, header := range strings.Split(params["headers"], " ") {
+		var value string
+		switch header {
+		case "(request-target)":
+			value = strings.ToLower(r.Method) + " " + r.URL.RequestURI()
+		case "host":
+			value = r.Host
+		case "content-length":
+			value = strconv.FormatInt(r.ContentLength, 10)
+		default:
+			value = r.Header.Get(header)
+		}
+		lines = append(lines, header+": "+value)
+	}
+
+	if r.ContentLength > 0 {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return err
+		}
+		digest := sha256.Sum256(body)
+		if r.Header.Get("Digest") != "SHA-256="+base64.StdEncoding.EncodeToString(digest[:]) {
+			return errors.New("digest does not match the body")
+		}
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(params["signature"])
+	if err != nil {
+		return err
+	}
+	hashed := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, hashed[:], signature)
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, hashed[:], signature) {
+			return errors.New("invalid ecdsa signature")
+		}
+		return nil
+	}
+
+	return errors.New("unsupported public key")
+}
+
+// newVerifyingServer returns a server that rejects requests without a valid signature
+func newVerifyingServer(publicKey crypto.PublicKey, headers chan<- string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Get("Authorization")
+
+		err := verifySignature(r, publicKey)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error_code": "Unauthorized", "error_message": "` + err.Error() + `"}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "bc8fb900-d6fd-41d0-b187-dc23ba928712", "type": "accounts", "attributes": {"country": "GB"}}}`))
+	}))
+}
+
+func Test_Unit_NewHTTPSigner_KeyFormats(t *testing.T) {
+	tests := []struct {
+		file      string
+		algorithm string
+	}{
+		{"rsa_private_key.pem", f3client.AlgorithmRSASHA256},
+		{"rsa_private_key_pkcs8.pem", f3client.AlgorithmRSASHA256},
+		{"ecdsa_private_key.pem", f3client.AlgorithmECDSASHA256},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.file, func(t *testing.T) {
+			signer, err := f3client.NewHTTPSigner("key-id", readTestFile(tc.file))
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.algorithm, signer.Algorithm())
+			}
+		})
+	}
+}
+
+func Test_Unit_NewHTTPSigner_InvalidKey(t *testing.T) {
+	_, err := f3client.NewHTTPSigner("key-id", []byte("not a key"))
+
+	var targetErr *f3client.ArgumentError
+	assert.ErrorAs(t, err, &targetErr)
+
+	_, err = f3client.NewHTTPSigner("key-id", readTestFile("rsa_public_key.pem"))
+	assert.ErrorAs(t, err, &targetErr)
+
+	_, err = f3client.NewHTTPSigner("", readTestFile("rsa_private_key.pem"))
+	assert.ErrorAs(t, err, &targetErr)
+}
+
+func Test_Unit_HTTPSigner_Fetch(t *testing.T) {
+	headers := make(chan string, 1)
+	server := newVerifyingServer(readPublicKey("rsa_public_key.pem"), headers)
+	defer server.Close()
+
+	signer, err := f3client.NewHTTPSigner("75a8ba12-fff2-4a52-ad8a-e8b34c5ccec8", readTestFile("rsa_private_key.pem"))
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithSigner(signer))
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = c.Accounts.Fetch(context.Background(), uuid.New())
+
+	assert.NoError(t, err)
+	assert.Contains(t, <-headers, `keyId="75a8ba12-fff2-4a52-ad8a-e8b34c5ccec8",algorithm="rsa-sha256",headers="(request-target) host date accept",`)
+}
+
+func Test_Unit_HTTPSigner_Create(t *testing.T) {
+	keys := []struct {
+		private string
+		public  string
+	}{
+		{"rsa_private_key.pem", "rsa_public_key.pem"},
+		{"ecdsa_private_key.pem", "ecdsa_public_key.pem"},
+	}
+
+	for _, key := range keys {
+		t.Run(key.private, func(t *testing.T) {
+			headers := make(chan string, 1)
+			server := newVerifyingServer(readPublicKey(key.public), headers)
+			defer server.Close()
+
+			signer, err := f3client.NewHTTPSigner("75a8ba12-fff2-4a52-ad8a-e8b34c5ccec8", readTestFile(key.private))
+			if err != nil {
+				panic(err)
+			}
+
+			c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithSigner(signer))
+			if err != nil {
+				panic(err)
+			}
+
+			account := &f3client.Account{
+				ID:             uuid.New(),
+				OrganisationID: uuid.New(),
+				Attributes: f3client.AccountAttributes{
+					Country:    "GB",
+					BankID:     "400300",
+					BankIDCode: f3client.BankIDCodeGB,
+					Bic:        "NWBKGB22",
+					Name:       []string{"Jon Doe"},
+				},
+			}
+
+			err = c.Accounts.Create(context.Background(), account)
+
+			assert.NoError(t, err)
+			assert.Contains(t, <-headers, `headers="(request-target) host date accept digest content-type content-length"`)
+		})
+	}
+}
+
+func Test_Unit_HTTPSigner_WrongKey(t *testing.T) {
+	headers := make(chan string, 1)
+	server := newVerifyingServer(readPublicKey("ecdsa_public_key.pem"), headers)
+	defer server.Close()
+
+	signer, err := f3client.NewHTTPSigner("75a8ba12-fff2-4a52-ad8a-e8b34c5ccec8", readTestFile("rsa_private_key.pem"))
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := f3client.NewClient(f3client.WithHostUrl(server.URL), f3client.WithSigner(signer))
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = c.Accounts.Fetch(context.Background(), uuid.New())
+	<-headers
+
+	var apiErr *f3client.APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	}
+}
@@ -0,0 +1,174 @@
+package f3client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type SubscriptionService struct {
+	service
+	ObjectType string
+}
+
+// CallbackTransport is the way the events of a subscription are delivered
+type CallbackTransport string
+
+// Transports the events of a subscription can be delivered with
+const (
+	CallbackTransportHttp  CallbackTransport = "http"
+	CallbackTransportQueue CallbackTransport = "queue"
+)
+
+// Subscription represents a subscription to the events of a form3 resource, the events
+// are delivered to the callback uri whenever a record of the record type changes.
+//
+// See the Subscriptions section of https://api-docs.form3.tech/api.html for
+// more information about fields.
+type Subscription struct {
+	ID             uuid.UUID              `json:"id,omitempty"`
+	Version        int                    `json:"version,omitempty"`
+	OrganisationID uuid.UUID              `json:"organisation_id,omitempty"`
+	CreatedOn      string                 `json:"created_on,omitempty"`
+	ModifiedOn     string                 `json:"modified_on,omitempty"`
+	Attributes     SubscriptionAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (s *Subscription) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, s, "type")
+}
+
+func (s Subscription) MarshalJSON() ([]byte, error) {
+	return marshalExtra(s)
+}
+
+type SubscriptionAttributes struct {
+	CallbackURI       string            `json:"callback_uri,omitempty"`
+	CallbackTransport CallbackTransport `json:"callback_transport,omitempty"`
+	EventType         string            `json:"event_type,omitempty"`
+	RecordType        string            `json:"record_type,omitempty"`
+	UserID            string            `json:"user_id,omitempty"`
+	Deleted           bool              `json:"deleted,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (sa *SubscriptionAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, sa)
+}
+
+func (sa SubscriptionAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(sa)
+}
+
+// validate checks the mandatory subscription fields
+func (sa *SubscriptionAttributes) validate() error {
+	if sa.CallbackURI == "" {
+		return NewArgError("callback_uri", "callback_uri is mandatory for subscription")
+	} else if sa.CallbackTransport != CallbackTransportHttp && sa.CallbackTransport != CallbackTransportQueue {
+		return NewArgError("callback_transport", "callback_transport must be either http or queue")
+	} else if sa.EventType == "" {
+		return NewArgError("event_type", "event_type is mandatory for subscription")
+	} else if sa.RecordType == "" {
+		return NewArgError("record_type", "record_type is mandatory for subscription")
+	}
+	return nil
+}
+
+// Create creates a subscription using form3 subscription api, the subscription is updated in place
+// with the subscription sent back by the api
+func (ss *SubscriptionService) Create(ctx context.Context, subscription *Subscription) error {
+	// validate for mandatory subscription fields before creating new request
+	err := subscription.Attributes.validate()
+	if err != nil {
+		return err
+	}
+
+	return ss.create(ctx, "/v1/notification/subscriptions", ss.ObjectType, subscription.ID, subscription)
+}
+
+// Fetch gets the notification subscription with the id
+func (ss *SubscriptionService) Fetch(ctx context.Context, subscriptionId uuid.UUID) (*Subscription, error) {
+	subscription := new(Subscription)
+
+	err := ss.fetch(ctx, "/v1/notification/subscriptions/"+subscriptionId.String(), ss.ObjectType, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// List gets a single page of form3 subscription objects, when opts is nil the first
+// page with the api default page size is returned
+func (ss *SubscriptionService) List(ctx context.Context, opts *ListOptions) ([]Subscription, Links, error) {
+	subscriptions := []Subscription{}
+
+	links, err := ss.list(ctx, addQuery("/v1/notification/subscriptions", opts.values()), ss.ObjectType, &subscriptions)
+	if err != nil {
+		return nil, Links{}, err
+	}
+
+	return subscriptions, links, nil
+}
+
+// Iterate returns a SubscriptionIterator that walks through all the subscriptions
+// starting from the page specified in opts, until there are no more pages left
+func (ss *SubscriptionService) Iterate(opts *ListOptions) *SubscriptionIterator {
+	return &SubscriptionIterator{
+		it: newResourceIterator(ss.client, ss.ObjectType, addQuery("/v1/notification/subscriptions", opts.values()), nil),
+	}
+}
+
+// Update updates the subscription with the attributes being passed, the subscription
+// is updated in place with the subscription sent back by the api
+//
+// The version of the subscription has to be the current one, otherwise *VersionConflictError is returned.
+func (ss *SubscriptionService) Update(ctx context.Context, subscription *Subscription) error {
+	if subscription.ID == uuid.Nil {
+		return NewArgError("id", "id is mandatory for subscription update request")
+	}
+
+	err := subscription.Attributes.validate()
+	if err != nil {
+		return err
+	}
+
+	path := "/v1/notification/subscriptions/" + subscription.ID.String()
+
+	return ss.update(ctx, path, ss.ObjectType, subscription.ID, subscription.Version, subscription)
+}
+
+// Delete deletes a subscription, needs the subscription id and version to be supplied
+func (ss *SubscriptionService) Delete(ctx context.Context, subscriptionId uuid.UUID, version int) error {
+	path := "/v1/notification/subscriptions/" + subscriptionId.String() + "?version=" + fmt.Sprint(version)
+
+	return ss.delete(ctx, path, ss.ObjectType)
+}
+
+// SubscriptionIterator iterates over the subscriptions returned by the list api one subscription
+// at a time, fetching the next page whenever the current one is exhausted
+type SubscriptionIterator struct {
+	it      resourceIterator
+	current Subscription
+}
+
+// Next advances the iterator to the next subscription, it returns false when there are no
+// more subscriptions left or an error occurs while fetching a page
+func (si *SubscriptionIterator) Next(ctx context.Context) bool {
+	si.current = Subscription{}
+	return si.it.next(ctx) && si.it.decode(&si.current)
+}
+
+// Subscription returns the subscription the iterator currently points to
+func (si *SubscriptionIterator) Subscription() Subscription {
+	return si.current
+}
+
+// Err returns the first error encountered while iterating, if any
+func (si *SubscriptionIterator) Err() error {
+	return si.it.err
+}
@@ -0,0 +1,214 @@
+package f3client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const subscriptionResponse = `
+	{
+		"data": {
+			"attributes": {
+				"callback_uri": "https://example.com/form3/events",
+				"callback_transport": "http",
+				"event_type": "created",
+				"record_type": "payments"
+			},
+			"id": "b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e",
+			"organisation_id": "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb",
+			"type": "subscriptions",
+			"version": 0
+		}
+	}`
+
+// newTestSubscription returns a valid subscription for the tests
+func newTestSubscription() *f3client.Subscription {
+	subscriptionUUID, _ := uuid.Parse("b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e")
+	organisationUUID, _ := uuid.Parse("743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb")
+
+	return &f3client.Subscription{
+		ID:             subscriptionUUID,
+		OrganisationID: organisationUUID,
+		Attributes: f3client.SubscriptionAttributes{
+			CallbackURI:       "https://example.com/form3/events",
+			CallbackTransport: f3client.CallbackTransportHttp,
+			EventType:         "created",
+			RecordType:        "payments",
+		},
+	}
+}
+
+func Test_Unit_SubscriptionService_Create(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(subscriptionResponse))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	subscription := newTestSubscription()
+
+	err = client.Subscriptions.Create(context.Background(), subscription)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	attributes := actualBody["data"]["attributes"].(map[string]interface{})
+	assert.Equal(t, "/v1/notification/subscriptions", actualPath)
+	assert.Equal(t, "subscriptions", actualBody["data"]["type"])
+	assert.Equal(t, "http", attributes["callback_transport"])
+	assert.Equal(t, "payments", attributes["record_type"])
+}
+
+func Test_Unit_SubscriptionService_Create_Invalid(t *testing.T) {
+	client, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	cases := map[string]func(*f3client.Subscription){
+		"no callback uri":   func(s *f3client.Subscription) { s.Attributes.CallbackURI = "" },
+		"unknown transport": func(s *f3client.Subscription) { s.Attributes.CallbackTransport = "email" },
+		"no event type":     func(s *f3client.Subscription) { s.Attributes.EventType = "" },
+		"no record type":    func(s *f3client.Subscription) { s.Attributes.RecordType = "" },
+	}
+
+	for name, invalidate := range cases {
+		subscription := newTestSubscription()
+		invalidate(subscription)
+
+		err = client.Subscriptions.Create(context.Background(), subscription)
+
+		var targetErr *f3client.ArgumentError
+		assert.ErrorAs(t, err, &targetErr, name)
+	}
+}
+
+func Test_Unit_SubscriptionService_Iterate(t *testing.T) {
+	// mock the server returning two pages
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page[number]") == "1" {
+			w.Write([]byte(`{"data": [{"id": "c3d4e5f6-a7b8-4c9d-8e0f-2a3b4c5d6e7f", "type": "subscriptions", "attributes": {"record_type": "accounts"}}]}`))
+			return
+		}
+		w.Write([]byte(`{"data": [{"id": "b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e", "type": "subscriptions", "attributes": {"record_type": "payments"}}],
+			"links": {"next": "/v1/notification/subscriptions?page[number]=1"}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	recordTypes := []string{}
+	it := client.Subscriptions.Iterate(nil)
+	for it.Next(context.Background()) {
+		recordTypes = append(recordTypes, it.Subscription().Attributes.RecordType)
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"payments", "accounts"}, recordTypes)
+}
+
+func Test_Unit_SubscriptionService_Update(t *testing.T) {
+	var actualMethod, actualPath string
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualMethod = r.Method
+		actualPath = r.URL.Path
+		w.Write([]byte(`{"data": {"id": "b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e", "type": "subscriptions", "version": 1,
+			"attributes": {"callback_uri": "https://example.com/v2/events", "callback_transport": "http", "event_type": "created", "record_type": "payments"}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	subscription := newTestSubscription()
+	subscription.Attributes.CallbackURI = "https://example.com/v2/events"
+
+	err = client.Subscriptions.Update(context.Background(), subscription)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, http.MethodPatch, actualMethod)
+	assert.Equal(t, "/v1/notification/subscriptions/b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e", actualPath)
+	assert.Equal(t, 1, subscription.Version)
+}
+
+func Test_Unit_SubscriptionService_Update_VersionConflict(t *testing.T) {
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error_message": "invalid version"}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	err = client.Subscriptions.Update(context.Background(), newTestSubscription())
+
+	var conflictErr *f3client.VersionConflictError
+	assert.True(t, errors.As(err, &conflictErr))
+}
+
+func Test_Unit_SubscriptionService_Delete(t *testing.T) {
+	var actualMethod, actualURL string
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualMethod = r.Method
+		actualURL = r.URL.String()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	subscriptionUUID, _ := uuid.Parse("b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e")
+
+	err = client.Subscriptions.Delete(context.Background(), subscriptionUUID, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodDelete, actualMethod)
+	assert.Equal(t, "/v1/notification/subscriptions/b2c3d4e5-f6a7-4b8c-9d0e-1f2a3b4c5d6e?version=3", actualURL)
+}
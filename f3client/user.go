@@ -0,0 +1,240 @@
+package f3client
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/google/uuid"
+)
+
+type UserService struct {
+	service
+	ObjectType string
+}
+
+// User represents a user in the form3 security section, the permissions of
+// the user are given by the roles assigned to it.
+//
+// See the Users section of https://api-docs.form3.tech/api.html for
+// more information about fields.
+type User struct {
+	ID             uuid.UUID      `json:"id,omitempty"`
+	Version        int            `json:"version,omitempty"`
+	OrganisationID uuid.UUID      `json:"organisation_id,omitempty"`
+	CreatedOn      string         `json:"created_on,omitempty"`
+	ModifiedOn     string         `json:"modified_on,omitempty"`
+	Attributes     UserAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (u *User) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, u, "type")
+}
+
+func (u User) MarshalJSON() ([]byte, error) {
+	return marshalExtra(u)
+}
+
+type UserAttributes struct {
+	Username string `json:"username,omitempty"`
+	Email    string `json:"email,omitempty"`
+	// RoleIDs is always sent, so that an empty list unassigns all the roles of the user on update
+	RoleIDs []uuid.UUID `json:"role_ids"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ua *UserAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ua)
+}
+
+func (ua UserAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ua)
+}
+
+// HasRole reports whether the role is assigned to the user
+func (u *User) HasRole(roleId uuid.UUID) bool {
+	for _, id := range u.Attributes.RoleIDs {
+		if id == roleId {
+			return true
+		}
+	}
+	return false
+}
+
+// Credential represents a credential a user authenticates to the apis with, example
+// the public key matching the private key requests are signed with.
+//
+// See the Credentials section of https://api-docs.form3.tech/api.html for
+// more information about fields.
+type Credential struct {
+	ID             uuid.UUID            `json:"id,omitempty"`
+	Version        int                  `json:"version,omitempty"`
+	OrganisationID uuid.UUID            `json:"organisation_id,omitempty"`
+	CreatedOn      string               `json:"created_on,omitempty"`
+	ModifiedOn     string               `json:"modified_on,omitempty"`
+	Attributes     CredentialAttributes `json:"attributes,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (c *Credential) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, c, "type")
+}
+
+func (c Credential) MarshalJSON() ([]byte, error) {
+	return marshalExtra(c)
+}
+
+type CredentialAttributes struct {
+	// PublicKey is the PEM encoded public key of a public key credential
+	PublicKey string `json:"public_key,omitempty"`
+	Type      string `json:"type,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+func (ca *CredentialAttributes) UnmarshalJSON(data []byte) error {
+	return unmarshalExtra(data, ca)
+}
+
+func (ca CredentialAttributes) MarshalJSON() ([]byte, error) {
+	return marshalExtra(ca)
+}
+
+// userPath returns the path of a user, the credentials of the user live under it
+func userPath(userId uuid.UUID) string {
+	return "/v1/security/users/" + userId.String()
+}
+
+// Create creates a user using form3 user api, the user is updated in place
+// with the user sent back by the api
+func (us *UserService) Create(ctx context.Context, user *User) error {
+	// validate for mandatory user fields before creating new request
+	if user.Attributes.Username == "" {
+		return NewArgError("username", "username is mandatory for user create request")
+	} else if user.Attributes.Email == "" {
+		return NewArgError("email", "email is mandatory for user create request")
+	}
+
+	// a user without roles is sent with an empty list of roles rather than null
+	if user.Attributes.RoleIDs == nil {
+		user.Attributes.RoleIDs = []uuid.UUID{}
+	}
+
+	return us.create(ctx, "/v1/security/users", us.ObjectType, user.ID, user)
+}
+
+// Fetch gets the user with the id, the roles assigned to the user are held in Attributes.RoleIDs
+func (us *UserService) Fetch(ctx context.Context, userId uuid.UUID) (*User, error) {
+	user := new(User)
+
+	err := us.fetch(ctx, userPath(userId), us.ObjectType, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// List gets a single page of form3 user objects, when opts is nil the first
+// page with the api default page size is returned
+func (us *UserService) List(ctx context.Context, opts *ListOptions) ([]User, Links, error) {
+	users := []User{}
+
+	links, err := us.list(ctx, addQuery("/v1/security/users", opts.values()), us.ObjectType, &users)
+	if err != nil {
+		return nil, Links{}, err
+	}
+
+	return users, links, nil
+}
+
+// AddRole assigns the role to the user, the user is updated in place with the user sent back by the api.
+// Nothing is sent when the role is already assigned to the user.
+//
+// The version of the user has to be the current one, otherwise *VersionConflictError is returned.
+func (us *UserService) AddRole(ctx context.Context, user *User, roleId uuid.UUID) error {
+	if user.HasRole(roleId) {
+		return nil
+	}
+
+	roleIds := append([]uuid.UUID{}, user.Attributes.RoleIDs...)
+
+	return us.updateRoles(ctx, user, append(roleIds, roleId))
+}
+
+// RemoveRole unassigns the role from the user, the user is updated in place with the user sent back
+// by the api. Nothing is sent when the role is not assigned to the user.
+//
+// The version of the user has to be the current one, otherwise *VersionConflictError is returned.
+func (us *UserService) RemoveRole(ctx context.Context, user *User, roleId uuid.UUID) error {
+	if !user.HasRole(roleId) {
+		return nil
+	}
+
+	roleIds := []uuid.UUID{}
+	for _, id := range user.Attributes.RoleIDs {
+		if id != roleId {
+			roleIds = append(roleIds, id)
+		}
+	}
+
+	return us.updateRoles(ctx, user, roleIds)
+}
+
+// updateRoles patches the roles of the user, the user is only changed when the patch succeeds
+func (us *UserService) updateRoles(ctx context.Context, user *User, roleIds []uuid.UUID) error {
+	if roleIds == nil {
+		roleIds = []uuid.UUID{}
+	}
+
+	updated := *user
+	updated.Attributes.RoleIDs = roleIds
+
+	err := us.update(ctx, userPath(user.ID), us.ObjectType, user.ID, user.Version, &updated)
+	if err != nil {
+		return err
+	}
+
+	*user = updated
+	return nil
+}
+
+// CreatePublicKeyCredential registers a public key for the user, requests signed with the matching
+// private key are authenticated as the user. The credential is updated in place with the credential
+// sent back by the api.
+//
+// The public key must be a PEM encoded PKIX public key.
+func (us *UserService) CreatePublicKeyCredential(ctx context.Context, userId uuid.UUID, credential *Credential) error {
+	// validate the key before creating new request, a private key must never be sent
+	block, _ := pem.Decode([]byte(credential.Attributes.PublicKey))
+	if block == nil {
+		return NewArgError("public_key", "no PEM encoded public key found")
+	}
+	if _, err := x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+		return NewArgError("public_key", "public_key is not a PKIX public key")
+	}
+
+	return us.create(ctx, userPath(userId)+"/credentials/public_key", "credentials", credential.ID, credential)
+}
+
+// ListCredentials gets a single page of the credentials of the user, when opts is nil the first
+// page with the api default page size is returned
+func (us *UserService) ListCredentials(ctx context.Context, userId uuid.UUID, opts *ListOptions) ([]Credential, Links, error) {
+	credentials := []Credential{}
+
+	links, err := us.list(ctx, addQuery(userPath(userId)+"/credentials", opts.values()), "credentials", &credentials)
+	if err != nil {
+		return nil, Links{}, err
+	}
+
+	return credentials, links, nil
+}
+
+// DeleteCredential deletes a credential of the user, requests can no longer be authenticated with it
+func (us *UserService) DeleteCredential(ctx context.Context, userId, credentialId uuid.UUID) error {
+	return us.delete(ctx, userPath(userId)+"/credentials/"+credentialId.String(), "credentials")
+}
@@ -0,0 +1,341 @@
+package f3client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testUserID  = uuid.MustParse("d1e2f3a4-b5c6-4d7e-8f9a-0b1c2d3e4f5a")
+	adminRoleID = uuid.MustParse("e2f3a4b5-c6d7-4e8f-9a0b-1c2d3e4f5a6b")
+	readRoleID  = uuid.MustParse("f3a4b5c6-d7e8-4f9a-8b1c-2d3e4f5a6b7c")
+)
+
+// newRolesServer echoes the patched user back with the next version
+func newRolesServer(actualBody *map[string]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(actualBody)
+		data := (*actualBody)["data"]
+		data["version"] = data["version"].(float64) + 1
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+}
+
+func Test_Unit_UserService_Create(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "d1e2f3a4-b5c6-4d7e-8f9a-0b1c2d3e4f5a", "type": "users",
+			"attributes": {"username": "jdoe", "email": "jdoe@example.com", "role_ids": ["e2f3a4b5-c6d7-4e8f-9a0b-1c2d3e4f5a6b"]}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	user := &f3client.User{
+		ID:             testUserID,
+		OrganisationID: uuid.New(),
+		Attributes: f3client.UserAttributes{
+			Username: "jdoe",
+			Email:    "jdoe@example.com",
+			RoleIDs:  []uuid.UUID{adminRoleID},
+		},
+	}
+
+	err = client.Users.Create(context.Background(), user)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/security/users", actualPath)
+	assert.Equal(t, "users", actualBody["data"]["type"])
+	assert.True(t, user.HasRole(adminRoleID))
+}
+
+func Test_Unit_UserService_Create_WithoutRoles(t *testing.T) {
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "d1e2f3a4-b5c6-4d7e-8f9a-0b1c2d3e4f5a", "type": "users",
+			"attributes": {"username": "jdoe", "email": "jdoe@example.com", "role_ids": []}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	user := &f3client.User{
+		ID:             testUserID,
+		OrganisationID: uuid.New(),
+		Attributes:     f3client.UserAttributes{Username: "jdoe", Email: "jdoe@example.com"},
+	}
+
+	err = client.Users.Create(context.Background(), user)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	// the roles are sent as an empty list, not as null
+	attributes := actualBody["data"]["attributes"].(map[string]interface{})
+	assert.Equal(t, []interface{}{}, attributes["role_ids"])
+	assert.Empty(t, user.Attributes.RoleIDs)
+}
+
+func Test_Unit_UserService_Create_MissingFields(t *testing.T) {
+	client, err := f3client.NewClient()
+	if err != nil {
+		panic(err)
+	}
+
+	cases := map[string]f3client.UserAttributes{
+		"no username": {Email: "jdoe@example.com"},
+		"no email":    {Username: "jdoe"},
+	}
+
+	for name, attributes := range cases {
+		err = client.Users.Create(context.Background(), &f3client.User{ID: uuid.New(), OrganisationID: uuid.New(), Attributes: attributes})
+
+		var targetErr *f3client.ArgumentError
+		assert.ErrorAs(t, err, &targetErr, name)
+	}
+}
+
+func Test_Unit_UserService_AddRole(t *testing.T) {
+	var actualBody map[string]map[string]interface{}
+
+	server := newRolesServer(&actualBody)
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	user := &f3client.User{
+		ID:             testUserID,
+		OrganisationID: uuid.New(),
+		Attributes:     f3client.UserAttributes{Username: "jdoe", RoleIDs: []uuid.UUID{adminRoleID}},
+	}
+
+	err = client.Users.AddRole(context.Background(), user, readRoleID)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	roleIds := actualBody["data"]["attributes"].(map[string]interface{})["role_ids"]
+	assert.Equal(t, []interface{}{adminRoleID.String(), readRoleID.String()}, roleIds)
+	assert.Equal(t, []uuid.UUID{adminRoleID, readRoleID}, user.Attributes.RoleIDs)
+	assert.Equal(t, 1, user.Version)
+
+	// adding the role again does not send anything
+	actualBody = nil
+	err = client.Users.AddRole(context.Background(), user, readRoleID)
+	assert.NoError(t, err)
+	assert.Nil(t, actualBody)
+}
+
+func Test_Unit_UserService_RemoveRole(t *testing.T) {
+	var actualBody map[string]map[string]interface{}
+
+	server := newRolesServer(&actualBody)
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	user := &f3client.User{
+		ID:             testUserID,
+		OrganisationID: uuid.New(),
+		Attributes:     f3client.UserAttributes{Username: "jdoe", RoleIDs: []uuid.UUID{adminRoleID, readRoleID}},
+	}
+
+	err = client.Users.RemoveRole(context.Background(), user, adminRoleID)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, []uuid.UUID{readRoleID}, user.Attributes.RoleIDs)
+	assert.False(t, user.HasRole(adminRoleID))
+}
+
+func Test_Unit_UserService_RemoveRole_LastRole(t *testing.T) {
+	var actualBody map[string]map[string]interface{}
+
+	server := newRolesServer(&actualBody)
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	user := &f3client.User{
+		ID:             testUserID,
+		OrganisationID: uuid.New(),
+		Attributes:     f3client.UserAttributes{Username: "jdoe", RoleIDs: []uuid.UUID{adminRoleID}},
+	}
+
+	err = client.Users.RemoveRole(context.Background(), user, adminRoleID)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	attributes := actualBody["data"]["attributes"].(map[string]interface{})
+	assert.Equal(t, []interface{}{}, attributes["role_ids"])
+	assert.Empty(t, user.Attributes.RoleIDs)
+	assert.False(t, user.HasRole(adminRoleID))
+}
+
+func Test_Unit_UserService_AddRole_VersionConflict(t *testing.T) {
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error_message": "invalid version"}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	user := &f3client.User{ID: testUserID, OrganisationID: uuid.New()}
+
+	err = client.Users.AddRole(context.Background(), user, readRoleID)
+
+	var conflictErr *f3client.VersionConflictError
+	assert.True(t, errors.As(err, &conflictErr))
+	assert.Empty(t, user.Attributes.RoleIDs)
+}
+
+func Test_Unit_UserService_CreatePublicKeyCredential(t *testing.T) {
+	var actualPath string
+	var actualBody map[string]map[string]interface{}
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&actualBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"id": "a4b5c6d7-e8f9-4a0b-9c1d-2e3f4a5b6c7d", "type": "credentials", "attributes": {"type": "public_key"}}}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	credential := &f3client.Credential{
+		ID:             uuid.New(),
+		OrganisationID: uuid.New(),
+		Attributes:     f3client.CredentialAttributes{PublicKey: string(readTestFile("rsa_public_key.pem"))},
+	}
+
+	err = client.Users.CreatePublicKeyCredential(context.Background(), testUserID, credential)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+
+	assert.Equal(t, "/v1/security/users/d1e2f3a4-b5c6-4d7e-8f9a-0b1c2d3e4f5a/credentials/public_key", actualPath)
+	assert.Equal(t, "credentials", actualBody["data"]["type"])
+	assert.Equal(t, "public_key", credential.Attributes.Type)
+}
+
+func Test_Unit_UserService_CreatePublicKeyCredential_PrivateKey(t *testing.T) {
+	var calls int
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	credential := &f3client.Credential{
+		ID:             uuid.New(),
+		OrganisationID: uuid.New(),
+		Attributes:     f3client.CredentialAttributes{PublicKey: string(readTestFile("rsa_private_key.pem"))},
+	}
+
+	err = client.Users.CreatePublicKeyCredential(context.Background(), testUserID, credential)
+
+	var targetErr *f3client.ArgumentError
+	assert.ErrorAs(t, err, &targetErr)
+	assert.Equal(t, 0, calls)
+}
+
+func Test_Unit_UserService_ListAndDeleteCredentials(t *testing.T) {
+	var actualMethod, actualPath string
+
+	// mock the server and json response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualMethod = r.Method
+		actualPath = r.URL.Path
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Write([]byte(`{"data": [{"id": "a4b5c6d7-e8f9-4a0b-9c1d-2e3f4a5b6c7d", "type": "credentials", "attributes": {"type": "public_key"}}]}`))
+	}))
+
+	// close the server once this test is done executing
+	defer server.Close()
+
+	client, err := f3client.NewClient(f3client.WithHostUrl(server.URL))
+	if err != nil {
+		panic(err)
+	}
+
+	credentials, _, err := client.Users.ListCredentials(context.Background(), testUserID, nil)
+	if err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.Equal(t, "/v1/security/users/d1e2f3a4-b5c6-4d7e-8f9a-0b1c2d3e4f5a/credentials", actualPath)
+
+	if assert.Len(t, credentials, 1) {
+		err = client.Users.DeleteCredential(context.Background(), testUserID, credentials[0].ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodDelete, actualMethod)
+		assert.Equal(t, "/v1/security/users/d1e2f3a4-b5c6-4d7e-8f9a-0b1c2d3e4f5a/credentials/a4b5c6d7-e8f9-4a0b-9c1d-2e3f4a5b6c7d", actualPath)
+	}
+}
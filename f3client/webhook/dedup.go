@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Deduplicator remembers the events that have been handled, so that an event delivered
+// more than once is only dispatched once, see WithDeduplicator
+type Deduplicator interface {
+	// Claim reports whether the event has to be handled, i.e. it has not been claimed before
+	Claim(eventID uuid.UUID) bool
+
+	// Release forgets a claimed event, so that it is handled again when it is redelivered
+	Release(eventID uuid.UUID)
+}
+
+// MemoryDeduplicator is a Deduplicator keeping the ids of the events it has seen in memory
+// for the ttl, it is safe for concurrent use
+//
+// Form3 redelivers events for a limited time only, so the ttl only has to cover that window.
+type MemoryDeduplicator struct {
+	mu   sync.Mutex
+	ttl  time.Duration
+	seen map[uuid.UUID]time.Time
+	// claims holds the claims in the order they expire, as every claim is kept for the same ttl
+	claims []claim
+	now    func() time.Time
+}
+
+// claim is an event claimed until expiry
+type claim struct {
+	eventID uuid.UUID
+	expiry  time.Time
+}
+
+// NewMemoryDeduplicator creates a MemoryDeduplicator remembering events for the ttl
+func NewMemoryDeduplicator(ttl time.Duration) *MemoryDeduplicator {
+	return &MemoryDeduplicator{
+		ttl:  ttl,
+		seen: map[uuid.UUID]time.Time{},
+		now:  time.Now,
+	}
+}
+
+// Claim reports whether the event has not been claimed within the ttl and claims it
+func (d *MemoryDeduplicator) Claim(eventID uuid.UUID) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	d.prune(now)
+
+	if _, ok := d.seen[eventID]; ok {
+		return false
+	}
+
+	expiry := now.Add(d.ttl)
+	d.seen[eventID] = expiry
+	d.claims = append(d.claims, claim{eventID: eventID, expiry: expiry})
+	return true
+}
+
+// Release forgets the event
+func (d *MemoryDeduplicator) Release(eventID uuid.UUID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.seen, eventID)
+}
+
+// prune forgets the events whose ttl has passed, only the expired claims are visited
+func (d *MemoryDeduplicator) prune(now time.Time) {
+	for len(d.claims) > 0 && now.After(d.claims[0].expiry) {
+		c := d.claims[0]
+		d.claims = d.claims[1:]
+
+		// the event might have been released and claimed again since
+		if expiry, ok := d.seen[c.eventID]; ok && expiry.Equal(c.expiry) {
+			delete(d.seen, c.eventID)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+)
+
+// EventType identifies the kind of an event, it is made of the record type
+// and the event type of the notification, example "accounts.created"
+type EventType string
+
+// Event types of the records supported by the typed accessors of Event
+const (
+	AccountCreated EventType = "accounts.created"
+	AccountUpdated EventType = "accounts.updated"
+	AccountDeleted EventType = "accounts.deleted"
+
+	PaymentCreated EventType = "payments.created"
+	PaymentUpdated EventType = "payments.updated"
+
+	// PaymentSubmissionUpdated is sent whenever the status of a payment submission changes
+	PaymentSubmissionUpdated EventType = "payment_submissions.updated"
+
+	// ReturnCreated is sent when a return is received for an outbound payment
+	ReturnCreated EventType = "returns.created"
+
+	ReturnSubmissionUpdated EventType = "return_submissions.updated"
+
+	ReversalCreated EventType = "reversals.created"
+	RecallCreated   EventType = "recalls.created"
+)
+
+// Event is a notification sent by form3 to the callback uri of a subscription
+//
+// Data holds the record the event is about, it can be decoded with Decode
+// or one of the typed accessors matching the record type.
+type Event struct {
+	ID             uuid.UUID       `json:"id"`
+	OrganisationID uuid.UUID       `json:"organisation_id"`
+	Version        int             `json:"version"`
+	EventType      string          `json:"event_type"`
+	RecordType     string          `json:"record_type"`
+	Data           json.RawMessage `json:"data"`
+}
+
+// Type returns the event type the event is dispatched with
+func (e *Event) Type() EventType {
+	return EventType(e.RecordType + "." + e.EventType)
+}
+
+// decodeEvent decodes the envelope of a notification
+func decodeEvent(body []byte) (*Event, error) {
+	event := new(Event)
+
+	err := json.Unmarshal(body, event)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.ID == uuid.Nil {
+		return nil, errors.New("event id is missing")
+	} else if event.RecordType == "" || event.EventType == "" {
+		return nil, errors.New("event record_type and event_type are mandatory")
+	}
+
+	return event, nil
+}
+
+// Decode decodes the record of the event into the type being passed
+func (e *Event) Decode(record interface{}) error {
+	if len(e.Data) == 0 {
+		return errors.New("event " + e.ID.String() + " carries no data")
+	}
+
+	return json.Unmarshal(e.Data, record)
+}
+
+// Account decodes the record of an accounts event
+func (e *Event) Account() (*f3client.Account, error) {
+	account := new(f3client.Account)
+
+	err := e.decodeRecord("accounts", account)
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// Payment decodes the record of a payments event
+func (e *Event) Payment() (*f3client.Payment, error) {
+	payment := new(f3client.Payment)
+
+	err := e.decodeRecord("payments", payment)
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// PaymentSubmission decodes the record of a payment_submissions event
+func (e *Event) PaymentSubmission() (*f3client.PaymentSubmission, error) {
+	submission := new(f3client.PaymentSubmission)
+
+	err := e.decodeRecord("payment_submissions", submission)
+	if err != nil {
+		return nil, err
+	}
+
+	return submission, nil
+}
+
+// Return decodes the record of a returns event
+func (e *Event) Return() (*f3client.Return, error) {
+	ret := new(f3client.Return)
+
+	err := e.decodeRecord("returns", ret)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// ReturnSubmission decodes the record of a return_submissions event
+func (e *Event) ReturnSubmission() (*f3client.ReturnSubmission, error) {
+	submission := new(f3client.ReturnSubmission)
+
+	err := e.decodeRecord("return_submissions", submission)
+	if err != nil {
+		return nil, err
+	}
+
+	return submission, nil
+}
+
+// Reversal decodes the record of a reversals event
+func (e *Event) Reversal() (*f3client.Reversal, error) {
+	reversal := new(f3client.Reversal)
+
+	err := e.decodeRecord("reversals", reversal)
+	if err != nil {
+		return nil, err
+	}
+
+	return reversal, nil
+}
+
+// Recall decodes the record of a recalls event
+func (e *Event) Recall() (*f3client.Recall, error) {
+	recall := new(f3client.Recall)
+
+	err := e.decodeRecord("recalls", recall)
+	if err != nil {
+		return nil, err
+	}
+
+	return recall, nil
+}
+
+// decodeRecord decodes the record after checking that the event is about the record type
+func (e *Event) decodeRecord(recordType string, record interface{}) error {
+	if e.RecordType != recordType {
+		return f3client.NewArgError("record_type", "event is about "+e.RecordType+" not "+recordType)
+	}
+
+	return e.Decode(record)
+}
@@ -0,0 +1,173 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+)
+
+// DefaultMaxBodySize is the largest notification body accepted by a Handler
+const DefaultMaxBodySize int64 = 1 << 20
+
+// HandlerFunc handles a single event, returning an error makes the Handler respond with
+// a 500 status code so that form3 delivers the event again
+type HandlerFunc func(ctx context.Context, event *Event) error
+
+// Handler is an http.Handler receiving the notifications form3 sends to the callback uri of a
+// subscription. Every notification is verified, decoded into an Event and dispatched to the
+// HandlerFunc registered for its event type.
+//
+// Events that are delivered more than once are only dispatched once, events without a
+// registered HandlerFunc are acknowledged without being dispatched.
+//
+// Example
+//
+//	verifier, err := webhook.NewSignatureVerifier(keyID, publicKeyPEM)
+//	handler, err := webhook.NewHandler(verifier)
+//	handler.Handle(webhook.PaymentSubmissionUpdated, func(ctx context.Context, event *webhook.Event) error {
+//		submission, err := event.PaymentSubmission()
+//		...
+//	})
+//	http.Handle("/form3/events", handler)
+type Handler struct {
+	verifier    Verifier
+	dedup       Deduplicator
+	maxBodySize int64
+
+	mu       sync.RWMutex
+	handlers map[EventType]HandlerFunc
+	fallback HandlerFunc
+}
+
+type Option func(*Handler) error
+
+// NewHandler creates a Handler verifying every notification with the verifier being passed
+//
+// By default events are deduplicated in memory for 24 hours, see WithDeduplicator.
+func NewHandler(verifier Verifier, options ...Option) (*Handler, error) {
+	if verifier == nil {
+		return nil, f3client.NewArgError("verifier", "verifier cannot be nil")
+	}
+
+	h := &Handler{
+		verifier:    verifier,
+		dedup:       NewMemoryDeduplicator(24 * time.Hour),
+		maxBodySize: DefaultMaxBodySize,
+		handlers:    map[EventType]HandlerFunc{},
+	}
+
+	for _, option := range options {
+		err := option(h)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return h, nil
+}
+
+// WithDeduplicator configures the Handler to deduplicate events with the deduplicator being passed,
+// example one backed by a shared store when the handler runs on more than one instance
+func WithDeduplicator(dedup Deduplicator) Option {
+	f := func(h *Handler) error {
+		if dedup == nil {
+			return f3client.NewArgError("dedup", "dedup cannot be nil")
+		}
+		h.dedup = dedup
+		return nil
+	}
+	return f
+}
+
+// WithMaxBodySize configures the Handler to reject notifications with a body larger than size
+func WithMaxBodySize(size int64) Option {
+	f := func(h *Handler) error {
+		if size <= 0 {
+			return f3client.NewArgError("size", "size must be positive")
+		}
+		h.maxBodySize = size
+		return nil
+	}
+	return f
+}
+
+// Handle registers the func handling the events of the event type, replacing any func registered before
+func (h *Handler) Handle(eventType EventType, fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.handlers[eventType] = fn
+}
+
+// HandleDefault registers the func handling the events without a func registered for their event type
+func (h *Handler) HandleDefault(fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.fallback = fn
+}
+
+// handlerFor returns the func handling the event type, nil if there is none
+func (h *Handler) handlerFor(eventType EventType) HandlerFunc {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if fn, ok := h.handlers[eventType]; ok {
+		return fn
+	}
+	return h.fallback
+}
+
+// ServeHTTP verifies, decodes and dispatches a notification
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// one byte more than the limit is read to tell a body that is too large from one that is not
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, h.maxBodySize+1))
+	if err != nil {
+		http.Error(w, "could not read notification : "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if int64(len(body)) > h.maxBodySize {
+		http.Error(w, "notification body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	err = h.verifier.Verify(r, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	event, err := decodeEvent(body)
+	if err != nil {
+		http.Error(w, "invalid notification : "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fn := h.handlerFor(event.Type())
+	if fn == nil || !h.dedup.Claim(event.ID) {
+		// nothing to do, acknowledge the event so that it is not delivered again
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	err = fn(r.Context(), event)
+	if err != nil {
+		// let form3 deliver the event again
+		h.dedup.Release(event.ID)
+		http.Error(w, "event could not be handled", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
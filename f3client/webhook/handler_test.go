@@ -0,0 +1,404 @@
+package webhook_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/iotest"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/benjaminmishra/form3-client-go/v1/f3client/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+const testKeyID = "75a8ba12-fff2-4a52-ad8a-e8b34c5ccec8"
+
+// readTestFile reads a file from the testdata folder
+func readTestFile(name string) []byte {
+	content, err := ioutil.ReadFile("testdata/" + name)
+	if err != nil {
+		panic(err)
+	}
+	return content
+}
+
+// readKey reads a key from the testdata folder of the f3client package
+func readKey(name string) []byte {
+	content, err := ioutil.ReadFile("../testdata/" + name)
+	if err != nil {
+		panic(err)
+	}
+	return content
+}
+
+// newTestHandler returns a handler verifying notifications signed with the rsa test key
+func newTestHandler(options ...webhook.Option) *webhook.Handler {
+	verifier, err := webhook.NewSignatureVerifier(testKeyID, readKey("rsa_public_key.pem"))
+	if err != nil {
+		panic(err)
+	}
+
+	handler, err := webhook.NewHandler(verifier, options...)
+	if err != nil {
+		panic(err)
+	}
+	return handler
+}
+
+// deliver posts the notification to the server, signed with the rsa test key
+func deliver(serverURL string, body []byte) *http.Response {
+	return deliverWith(serverURL, body, testKeyID, "rsa_private_key.pem", nil)
+}
+
+// deliverWith posts the notification to the server signed with the private key, tamper
+// is called after the notification is signed
+func deliverWith(serverURL string, body []byte, keyID, privateKey string, tamper func(*http.Request)) *http.Response {
+	signer, err := f3client.NewHTTPSigner(keyID, readKey(privateKey))
+	if err != nil {
+		panic(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, serverURL+"/form3/events", bytes.NewReader(body))
+	if err != nil {
+		panic(err)
+	}
+	req.Header.Set("Accept", f3client.Accepts)
+
+	err = signer.Sign(req, body)
+	if err != nil {
+		panic(err)
+	}
+
+	if tamper != nil {
+		tamper(req)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		panic(err)
+	}
+	resp.Body.Close()
+
+	return resp
+}
+
+func Test_Unit_Handler_AccountCreated(t *testing.T) {
+	handler := newTestHandler()
+
+	var received *f3client.Account
+	handler.Handle(webhook.AccountCreated, func(ctx context.Context, event *webhook.Event) error {
+		account, err := event.Account()
+		received = account
+		return err
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp := deliver(server.URL, readTestFile("account_created.json"))
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	if assert.NotNil(t, received) {
+		assert.Equal(t, "bc8fb900-d6fd-41d0-b187-dc23ba928712", received.ID.String())
+		assert.Equal(t, f3client.Country("GB"), received.Attributes.Country)
+		assert.Equal(t, []string{"Jon Doe"}, received.Attributes.Name)
+	}
+}
+
+func Test_Unit_Handler_PaymentSubmissionUpdated(t *testing.T) {
+	handler := newTestHandler()
+
+	var received *f3client.PaymentSubmission
+	handler.Handle(webhook.PaymentSubmissionUpdated, func(ctx context.Context, event *webhook.Event) error {
+		submission, err := event.PaymentSubmission()
+		received = submission
+		return err
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp := deliver(server.URL, readTestFile("payment_submission_updated.json"))
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	if assert.NotNil(t, received) {
+		assert.Equal(t, f3client.SubmissionStatusDeliveryFailed, received.Attributes.Status)
+		assert.True(t, received.Attributes.Status.IsFailed())
+		assert.Equal(t, "Account closed", received.Attributes.StatusReason)
+	}
+}
+
+func Test_Unit_Handler_ReturnCreated(t *testing.T) {
+	handler := newTestHandler()
+
+	var received *f3client.Return
+	handler.Handle(webhook.ReturnCreated, func(ctx context.Context, event *webhook.Event) error {
+		ret, err := event.Return()
+		received = ret
+		return err
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp := deliver(server.URL, readTestFile("return_created.json"))
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	if assert.NotNil(t, received) {
+		assert.Equal(t, "AC01", received.Attributes.ReturnCode)
+		assert.Equal(t, "100.21", received.Attributes.Amount)
+	}
+}
+
+func Test_Unit_Handler_KeyRotation(t *testing.T) {
+	var calls int32
+
+	verifier, err := webhook.NewSignatureVerifier(testKeyID, readKey("rsa_public_key.pem"))
+	if err != nil {
+		panic(err)
+	}
+	err = verifier.AddKey("ecdsa-key", readKey("ecdsa_public_key.pem"))
+	if err != nil {
+		panic(err)
+	}
+
+	handler, err := webhook.NewHandler(verifier)
+	if err != nil {
+		panic(err)
+	}
+	handler.HandleDefault(func(ctx context.Context, event *webhook.Event) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// both the old and the new key are accepted
+	rsaResp := deliver(server.URL, readTestFile("account_created.json"))
+	ecdsaResp := deliverWith(server.URL, readTestFile("return_created.json"), "ecdsa-key", "ecdsa_private_key.pem", nil)
+
+	assert.Equal(t, http.StatusOK, rsaResp.StatusCode)
+	assert.Equal(t, http.StatusOK, ecdsaResp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func Test_Unit_Handler_AddKeyDuringDeliveries(t *testing.T) {
+	verifier, err := webhook.NewSignatureVerifier(testKeyID, readKey("rsa_public_key.pem"))
+	if err != nil {
+		panic(err)
+	}
+
+	handler, err := webhook.NewHandler(verifier)
+	if err != nil {
+		panic(err)
+	}
+	handler.HandleDefault(func(ctx context.Context, event *webhook.Event) error {
+		return nil
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// keys are rotated while notifications are being delivered, run with -race
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			resp := deliver(server.URL, readTestFile("account_created.json"))
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}()
+		go func(i int) {
+			defer wg.Done()
+			err := verifier.AddKey("ecdsa-key-"+strconv.Itoa(i), readKey("ecdsa_public_key.pem"))
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func Test_Unit_Handler_Duplicate(t *testing.T) {
+	var calls int32
+
+	handler := newTestHandler()
+	handler.Handle(webhook.AccountCreated, func(ctx context.Context, event *webhook.Event) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	first := deliver(server.URL, readTestFile("account_created.json"))
+	second := deliver(server.URL, readTestFile("account_created.json"))
+
+	assert.Equal(t, http.StatusOK, first.StatusCode)
+	assert.Equal(t, http.StatusOK, second.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func Test_Unit_Handler_HandlerError(t *testing.T) {
+	var calls int32
+
+	// fail the first time the event is handled
+	handler := newTestHandler()
+	handler.Handle(webhook.AccountCreated, func(ctx context.Context, event *webhook.Event) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	first := deliver(server.URL, readTestFile("account_created.json"))
+	second := deliver(server.URL, readTestFile("account_created.json"))
+
+	assert.Equal(t, http.StatusInternalServerError, first.StatusCode)
+	assert.Equal(t, http.StatusOK, second.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func Test_Unit_Handler_InvalidSignature(t *testing.T) {
+	cases := map[string]func(*http.Request){
+		"tampered body": func(r *http.Request) {
+			body := bytes.Replace(readTestFile("account_created.json"), []byte("Jon Doe"), []byte("Jon Roe"), 1)
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		},
+		"tampered digest": func(r *http.Request) {
+			r.Header.Set("Digest", "SHA-256=47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=")
+		},
+		"tampered path": func(r *http.Request) {
+			r.URL.Path = "/other/events"
+		},
+		"missing signature": func(r *http.Request) {
+			r.Header.Del("Authorization")
+		},
+		"unknown key": func(r *http.Request) {
+			r.Header.Set("Authorization", strings.Replace(r.Header.Get("Authorization"), testKeyID, "unknown", 1))
+		},
+	}
+
+	for name, tamper := range cases {
+		var calls int32
+
+		handler := newTestHandler()
+		handler.HandleDefault(func(ctx context.Context, event *webhook.Event) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		})
+
+		server := httptest.NewServer(handler)
+
+		resp := deliverWith(server.URL, readTestFile("account_created.json"), testKeyID, "rsa_private_key.pem", tamper)
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, name)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&calls), name)
+
+		server.Close()
+	}
+}
+
+func Test_Unit_Handler_Default(t *testing.T) {
+	handler := newTestHandler()
+
+	var received webhook.EventType
+	handler.Handle(webhook.AccountCreated, func(ctx context.Context, event *webhook.Event) error {
+		return errors.New("not expected")
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// without a default func the event is acknowledged
+	resp := deliver(server.URL, readTestFile("mandate_created.json"))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	handler.HandleDefault(func(ctx context.Context, event *webhook.Event) error {
+		received = event.Type()
+		return nil
+	})
+
+	// the event was not claimed, so the redelivery is dispatched
+	resp = deliver(server.URL, readTestFile("mandate_created.json"))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, webhook.EventType("mandates.created"), received)
+}
+
+func Test_Unit_Handler_BadRequests(t *testing.T) {
+	handler := newTestHandler(webhook.WithMaxBodySize(512))
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/form3/events")
+	if err != nil {
+		panic(err)
+	}
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	resp = deliver(server.URL, readTestFile("account_created.json"))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+
+	resp = deliver(server.URL, []byte(`{"record_type": "accounts"}`))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func Test_Unit_Handler_UnreadableBody(t *testing.T) {
+	handler := newTestHandler()
+
+	req := httptest.NewRequest(http.MethodPost, "/form3/events", iotest.ErrReader(errors.New("connection reset")))
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "connection reset")
+}
+
+func Test_Unit_NewHandler_InvalidArguments(t *testing.T) {
+	_, err := webhook.NewHandler(nil)
+
+	var targetErr *f3client.ArgumentError
+	assert.ErrorAs(t, err, &targetErr)
+
+	verifier, err := webhook.NewSignatureVerifier(testKeyID, readKey("rsa_public_key.pem"))
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = webhook.NewHandler(verifier, webhook.WithDeduplicator(nil))
+	assert.ErrorAs(t, err, &targetErr)
+
+	_, err = webhook.NewSignatureVerifier(testKeyID, readKey("rsa_private_key.pem"))
+	assert.Error(t, err)
+}
+
+func Test_Unit_Event_WrongRecordType(t *testing.T) {
+	handler := newTestHandler()
+
+	var accessorErr error
+	handler.Handle(webhook.AccountCreated, func(ctx context.Context, event *webhook.Event) error {
+		_, accessorErr = event.Payment()
+		return nil
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	deliver(server.URL, readTestFile("account_created.json"))
+
+	var targetErr *f3client.ArgumentError
+	assert.ErrorAs(t, accessorErr, &targetErr)
+}
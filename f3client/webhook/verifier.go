@@ -0,0 +1,203 @@
+package webhook
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/benjaminmishra/form3-client-go/v1/f3client/internal/httpsig"
+)
+
+// ErrInvalidSignature is returned when the signature of a notification is missing or does not
+// match, the returned errors can be matched against it using errors.Is
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Verifier checks that a notification was sent by form3, see NewHandler
+//
+// Verify is called with the received request and its body, which has already been read.
+type Verifier interface {
+	Verify(request *http.Request, body []byte) error
+}
+
+// SignatureVerifier verifies notifications signed with the form3 http message signature scheme, the
+// same scheme f3client.HTTPSigner signs requests with
+//
+// The signature is read from the Signature header, or from the Authorization header when it starts
+// with "Signature". It has to cover (request-target) and date, and digest when the notification has a
+// body. Notifications whose Date is further away than MaxSkew from the current time are rejected.
+//
+// Keys can be added with AddKey while notifications are being verified.
+type SignatureVerifier struct {
+	mu   sync.RWMutex
+	keys map[string]crypto.PublicKey
+
+	// MaxSkew is the maximum difference allowed between the Date header and the current time
+	MaxSkew time.Duration
+
+	now func() time.Time
+}
+
+// NewSignatureVerifier creates a SignatureVerifier accepting signatures made with the key id and the
+// matching public key, more keys can be added with AddKey when keys are rotated
+//
+// The public key must be a PEM encoded PKIX RSA or ECDSA key.
+func NewSignatureVerifier(keyID string, publicKeyPEM []byte) (*SignatureVerifier, error) {
+	v := &SignatureVerifier{
+		keys:    map[string]crypto.PublicKey{},
+		MaxSkew: 5 * time.Minute,
+		now:     time.Now,
+	}
+
+	err := v.AddKey(keyID, publicKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// AddKey adds a key id and the matching public key to the keys accepted by the verifier
+func (v *SignatureVerifier) AddKey(keyID string, publicKeyPEM []byte) error {
+	if keyID == "" {
+		return f3client.NewArgError("keyID", "keyID cannot be empty")
+	}
+
+	block, _ := pem.Decode(publicKeyPEM)
+	if block == nil {
+		return f3client.NewArgError("publicKeyPEM", "no PEM encoded key found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return err
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+	default:
+		return f3client.NewArgError("publicKeyPEM", "only RSA and ECDSA public keys are supported")
+	}
+
+	v.mu.Lock()
+	v.keys[keyID] = key
+	v.mu.Unlock()
+
+	return nil
+}
+
+// Verify checks the signature, the digest and the date of the notification
+func (v *SignatureVerifier) Verify(request *http.Request, body []byte) error {
+	params, err := signatureParams(request)
+	if err != nil {
+		return err
+	}
+
+	v.mu.RLock()
+	key, ok := v.keys[params["keyId"]]
+	v.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w : unknown key id %q", ErrInvalidSignature, params["keyId"])
+	}
+
+	headers := strings.Fields(params["headers"])
+	for _, required := range requiredHeaders(body) {
+		if !contains(headers, required) {
+			return fmt.Errorf("%w : %s is not signed", ErrInvalidSignature, required)
+		}
+	}
+
+	if len(body) > 0 {
+		digest := sha256.Sum256(body)
+		expected := "SHA-256=" + base64.StdEncoding.EncodeToString(digest[:])
+		if subtle.ConstantTimeCompare([]byte(request.Header.Get("Digest")), []byte(expected)) != 1 {
+			return fmt.Errorf("%w : digest does not match the body", ErrInvalidSignature)
+		}
+	}
+
+	date, err := http.ParseTime(request.Header.Get("Date"))
+	if err != nil {
+		return fmt.Errorf("%w : invalid date", ErrInvalidSignature)
+	}
+	if skew := v.now().Sub(date); skew > v.MaxSkew || skew < -v.MaxSkew {
+		return fmt.Errorf("%w : date is outside of the allowed skew", ErrInvalidSignature)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(params["signature"])
+	if err != nil {
+		return fmt.Errorf("%w : signature is not base64 encoded", ErrInvalidSignature)
+	}
+
+	hashed := sha256.Sum256([]byte(httpsig.SigningString(request, headers)))
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		err = rsa.VerifyPKCS1v15(k, crypto.SHA256, hashed[:], signature)
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, hashed[:], signature) {
+			err = errors.New("ecdsa verification failed")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%w : %s", ErrInvalidSignature, err.Error())
+	}
+
+	return nil
+}
+
+// signatureParams parses the parameters of the signature sent with the request
+func signatureParams(request *http.Request) (map[string]string, error) {
+	value := request.Header.Get("Signature")
+	if value == "" {
+		authorization := request.Header.Get("Authorization")
+		if !strings.HasPrefix(authorization, "Signature ") {
+			return nil, fmt.Errorf("%w : signature is missing", ErrInvalidSignature)
+		}
+		value = strings.TrimPrefix(authorization, "Signature ")
+	}
+
+	params := map[string]string{}
+	for _, param := range strings.Split(value, ",") {
+		kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%w : malformed signature parameter %q", ErrInvalidSignature, param)
+		}
+		params[kv[0]] = strings.Trim(kv[1], `"`)
+	}
+
+	for _, name := range []string{"keyId", "headers", "signature"} {
+		if params[name] == "" {
+			return nil, fmt.Errorf("%w : %s is missing", ErrInvalidSignature, name)
+		}
+	}
+
+	return params, nil
+}
+
+// requiredHeaders returns the headers the signature has to cover
+func requiredHeaders(body []byte) []string {
+	if len(body) > 0 {
+		return []string{"(request-target)", "date", "digest"}
+	}
+	return []string{"(request-target)", "date"}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	f3client "github.com/benjaminmishra/form3-client-go/v1/f3client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unit_SignatureVerifier_DateSkew(t *testing.T) {
+	privateKey, _ := ioutil.ReadFile("../testdata/rsa_private_key.pem")
+	publicKey, _ := ioutil.ReadFile("../testdata/rsa_public_key.pem")
+
+	signer, err := f3client.NewHTTPSigner("test-key", privateKey)
+	if err != nil {
+		panic(err)
+	}
+	verifier, err := NewSignatureVerifier("test-key", publicKey)
+	if err != nil {
+		panic(err)
+	}
+
+	body := []byte(`{"id": "6f2a7d3c-1b4e-4c8a-9e5f-0d1c2b3a4f5e"}`)
+	req, _ := http.NewRequest(http.MethodPost, "http://localhost/form3/events", bytes.NewReader(body))
+	err = signer.Sign(req, body)
+	if err != nil {
+		panic(err)
+	}
+
+	assert.NoError(t, verifier.Verify(req, body))
+
+	// the notification is replayed ten minutes later
+	verifier.now = func() time.Time { return time.Now().Add(10 * time.Minute) }
+	err = verifier.Verify(req, body)
+
+	assert.True(t, errors.Is(err, ErrInvalidSignature))
+}
+
+func Test_Unit_MemoryDeduplicator_Expiry(t *testing.T) {
+	now := time.Now()
+	dedup := NewMemoryDeduplicator(time.Hour)
+	dedup.now = func() time.Time { return now }
+
+	id := uuid.New()
+
+	assert.True(t, dedup.Claim(id))
+	assert.False(t, dedup.Claim(id))
+
+	// the event is forgotten once the ttl has passed
+	now = now.Add(2 * time.Hour)
+	assert.True(t, dedup.Claim(id))
+
+	dedup.Release(id)
+	assert.True(t, dedup.Claim(id))
+	assert.Len(t, dedup.seen, 1)
+}
+
+func Test_Unit_MemoryDeduplicator_ReleasedAndClaimedAgain(t *testing.T) {
+	now := time.Now()
+	dedup := NewMemoryDeduplicator(time.Hour)
+	dedup.now = func() time.Time { return now }
+
+	id := uuid.New()
+	assert.True(t, dedup.Claim(id))
+
+	// the event is claimed again half way through the ttl of the first claim
+	now = now.Add(30 * time.Minute)
+	dedup.Release(id)
+	assert.True(t, dedup.Claim(id))
+
+	// the first claim expiring does not forget the second one
+	now = now.Add(45 * time.Minute)
+	assert.False(t, dedup.Claim(id))
+	assert.Len(t, dedup.claims, 1)
+
+	now = now.Add(time.Hour)
+	assert.True(t, dedup.Claim(uuid.New()))
+	assert.Len(t, dedup.seen, 1)
+	assert.Len(t, dedup.claims, 1)
+}